@@ -3,6 +3,7 @@ package wlr
 /*
 #include <wayland-server-core.h>
 #include <wlr/backend.h>
+#include <wlr/backend/headless.h>
 #include <wlr/backend/wayland.h>
 #include <wlr/backend/x11.h>
 */
@@ -22,6 +23,34 @@ func AutocreateBackend(display Display) Backend {
 	return Backend{p: p}
 }
 
+// CreateHeadlessBackend creates a backend that has no physical
+// outputs or inputs. Outputs can be added to it with
+// AddHeadlessOutput, which makes it useful for running a compositor
+// on machines without a GPU or seat, such as for testing.
+//
+// It takes a Display rather than an EventLoop because that is what
+// wlr_headless_backend_create accepts in wlroots 0.17. Later versions
+// of wlroots take the event loop instead.
+func CreateHeadlessBackend(display Display) Backend {
+	checkThread()
+
+	p := C.wlr_headless_backend_create(display.p)
+	return Backend{p: p}
+}
+
+func (b Backend) IsHeadless() bool {
+	return bool(C.wlr_backend_is_headless(b.p))
+}
+
+// AddHeadlessOutput creates a new virtual output with the given
+// resolution. The backend must have been created with
+// CreateHeadlessBackend. The new output is emitted via OnNewOutput
+// in the same manner as a hardware output would be.
+func (b Backend) AddHeadlessOutput(width, height int) Output {
+	p := C.wlr_headless_add_output(b.p, C.uint(width), C.uint(height))
+	return Output{p: p}
+}
+
 func (b Backend) Valid() bool {
 	return b.p != nil
 }
@@ -0,0 +1,51 @@
+package wlr
+
+/*
+#include <wlr/types/wlr_buffer.h>
+*/
+import "C"
+
+import "unsafe"
+
+type Buffer struct {
+	p *C.struct_wlr_buffer
+}
+
+func (b Buffer) Valid() bool {
+	return b.p != nil
+}
+
+func (b Buffer) Width() int {
+	return int(b.p.width)
+}
+
+func (b Buffer) Height() int {
+	return int(b.p.height)
+}
+
+// Drop destroys the buffer as soon as it is no longer locked by
+// anything.
+func (b Buffer) Drop() {
+	C.wlr_buffer_drop(b.p)
+}
+
+func (b Buffer) Lock() Buffer {
+	p := C.wlr_buffer_lock(b.p)
+	return Buffer{p: p}
+}
+
+func (b Buffer) Unlock() {
+	C.wlr_buffer_unlock(b.p)
+}
+
+func (b Buffer) OnDestroy(cb func(Buffer)) Listener {
+	return newListener(&b.p.events.destroy, func(lis Listener, data unsafe.Pointer) {
+		cb(b)
+	})
+}
+
+func (b Buffer) OnRelease(cb func(Buffer)) Listener {
+	return newListener(&b.p.events.release, func(lis Listener, data unsafe.Pointer) {
+		cb(b)
+	})
+}
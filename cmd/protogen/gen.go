@@ -0,0 +1,411 @@
+package main
+
+import (
+	"fmt"
+	"go/token"
+	"io"
+	"strings"
+)
+
+type generator struct {
+	w     io.Writer
+	proto *Protocol
+	err   error
+
+	local map[string]*Interface
+}
+
+func newGenerator(w io.Writer, proto *Protocol) *generator {
+	g := generator{
+		w:     w,
+		proto: proto,
+		local: make(map[string]*Interface),
+	}
+
+	for i := range proto.Interfaces {
+		iface := &proto.Interfaces[i]
+		g.local[iface.Name] = iface
+	}
+
+	return &g
+}
+
+func (g *generator) printf(format string, args ...any) {
+	if g.err != nil {
+		return
+	}
+
+	_, g.err = fmt.Fprintf(g.w, format, args...)
+}
+
+func (g *generator) gen(pkg, source string) error {
+	g.printf("// Code generated by protogen from %v. DO NOT EDIT.\n\n", source)
+	g.printf("package %v\n\n", pkg)
+	g.printf("import \"deedles.dev/wlr\"\n\n")
+
+	g.genInterfaceVars()
+	for i := range g.proto.Interfaces {
+		g.genInterface(&g.proto.Interfaces[i])
+	}
+
+	return g.err
+}
+
+func (g *generator) genInterfaceVars() {
+	g.printf("var (\n")
+	for _, iface := range g.proto.Interfaces {
+		g.printf("%v = wlr.InterfaceByName(%q)\n", g.interfaceRef(iface.Name), iface.Name)
+	}
+	g.printf(")\n\n")
+
+	// Everything else is set in init so that interfaces which refer to
+	// each other don't cause an initialization cycle.
+	g.printf("func init() {\n")
+	for _, iface := range g.proto.Interfaces {
+		ref := g.interfaceRef(iface.Name)
+		g.printf("%v.Version = %v\n", ref, iface.Version)
+		g.genMessages(ref+".Requests", iface.Requests)
+		g.genMessages(ref+".Events", iface.Events)
+	}
+	g.printf("}\n\n")
+}
+
+func (g *generator) genMessages(field string, msgs []Message) {
+	if len(msgs) == 0 {
+		return
+	}
+
+	g.printf("%v = []wlr.Message{\n", field)
+	for _, msg := range msgs {
+		g.printf("{Name: %q, Signature: %q", msg.Name, msg.Signature())
+		if types := g.messageTypes(msg); types != nil {
+			g.printf(", Types: []*wlr.Interface{%v}", strings.Join(types, ", "))
+		}
+		g.printf("},\n")
+	}
+	g.printf("}\n")
+}
+
+func (g *generator) messageTypes(msg Message) []string {
+	var types []string
+	var hasType bool
+	for _, arg := range msg.Args {
+		switch {
+		case arg.Type == "new_id" && arg.Interface == "":
+			types = append(types, "nil", "nil", "nil")
+		case arg.Interface != "":
+			types = append(types, g.interfaceRef(arg.Interface))
+			hasType = true
+		default:
+			types = append(types, "nil")
+		}
+	}
+	if !hasType {
+		return nil
+	}
+	return types
+}
+
+func (g *generator) genInterface(iface *Interface) {
+	name := camel(iface.Name)
+
+	g.printf("// %v is a %v resource.", name, iface.Name)
+	g.printSummary(iface.Description.Summary)
+	g.printf("type %v struct {\nwlr.Resource\n}\n\n", name)
+
+	if len(iface.Requests) > 0 || len(iface.Events) > 0 {
+		g.printf("const (\n")
+		for _, msg := range iface.Requests {
+			g.printf("%v = %v\n", sinceVersion(iface, "Request", msg), msg.SinceVersion())
+		}
+		for _, msg := range iface.Events {
+			g.printf("%v = %v\n", sinceVersion(iface, "Event", msg), msg.SinceVersion())
+		}
+		g.printf(")\n\n")
+	}
+
+	for _, enum := range iface.Enums {
+		g.genEnum(iface, enum)
+	}
+
+	g.genHandler(iface)
+	g.genCreate(iface)
+
+	for opcode, msg := range iface.Events {
+		g.genEvent(iface, opcode, msg)
+	}
+}
+
+func (g *generator) genEnum(iface *Interface, enum Enum) {
+	name := camel(iface.Name) + camel(enum.Name)
+
+	g.printf("// %v is the %v.%v enum.", name, iface.Name, enum.Name)
+	g.printSummary(enum.Description.Summary)
+	g.printf("type %v uint32\n\n", name)
+
+	g.printf("const (\n")
+	for _, entry := range enum.Entries {
+		g.printf("%v%v %v = %v", name, camel(entry.Name), name, entry.Value)
+		if entry.Summary != "" {
+			g.printf(" // %v", entry.Summary)
+		}
+		g.printf("\n")
+	}
+	g.printf(")\n\n")
+}
+
+func (g *generator) genHandler(iface *Interface) {
+	name := camel(iface.Name)
+
+	g.printf("// %vHandler handles requests sent to a %v.\n", name, name)
+	g.printf("type %vHandler interface {\n", name)
+	for _, msg := range iface.Requests {
+		g.printf("// %v handles %v requests.", camel(msg.Name), msg.Name)
+		g.printSummary(msg.Description.Summary)
+		if msg.Type == "destructor" {
+			g.printf("//\n// The resource is destroyed after this returns.\n")
+		}
+
+		g.printf("%v(r %v", camel(msg.Name), name)
+		for _, param := range g.requestParams(iface, msg) {
+			g.printf(", %v %v", param.name, param.typ)
+		}
+		g.printf(")\n")
+	}
+	g.printf("}\n\n")
+}
+
+func (g *generator) genCreate(iface *Interface) {
+	name := camel(iface.Name)
+
+	g.printf("// Create%v creates a %v resource for client. Its requests\n// are handled by h.\n", name, iface.Name)
+	g.printf("func Create%v(client wlr.Client, version, id uint32, h %vHandler) %v {\n", name, name, name)
+	g.printf("r := %v{wlr.CreateResource(client, %v, version, id)}\n", name, g.interfaceRef(iface.Name))
+	g.printf("if !r.Valid() {\nreturn r\n}\n\n")
+
+	g.printf("r.SetDispatcher(func(_ wlr.Resource, opcode uint32, args []any) {\n")
+	if len(iface.Requests) > 0 {
+		g.printf("switch opcode {\n")
+		for opcode, msg := range iface.Requests {
+			g.printf("case %v:\n", opcode)
+
+			var vals []string
+			for _, param := range g.requestParams(iface, msg) {
+				vals = append(vals, param.decode)
+			}
+			g.printf("h.%v(%v)\n", camel(msg.Name), strings.Join(append([]string{"r"}, vals...), ", "))
+			if msg.Type == "destructor" {
+				g.printf("r.Destroy()\n")
+			}
+		}
+		g.printf("}\n")
+	}
+	g.printf("}, nil)\n\n")
+
+	g.printf("return r\n}\n\n")
+}
+
+func (g *generator) genEvent(iface *Interface, opcode int, msg Message) {
+	name := camel(iface.Name)
+
+	g.printf("// Send%v sends a %v event.", camel(msg.Name), msg.Name)
+	g.printSummary(msg.Description.Summary)
+	g.printf("//\n// The event is not sent if the resource's version is too old to\n// support it.\n")
+
+	params := g.eventParams(iface, msg)
+	g.printf("func (r %v) Send%v(", name, camel(msg.Name))
+	for i, param := range params {
+		if i > 0 {
+			g.printf(", ")
+		}
+		g.printf("%v %v", param.name, param.typ)
+	}
+	g.printf(") {\n")
+
+	g.printf("if r.Version() < %v {\nreturn\n}\n\n", sinceVersion(iface, "Event", msg))
+
+	vals := []string{fmt.Sprint(opcode)}
+	for _, param := range params {
+		vals = append(vals, param.encode)
+	}
+	g.printf("r.PostEvent(%v)\n", strings.Join(vals, ", "))
+	if msg.Type == "destructor" {
+		g.printf("r.Destroy()\n")
+	}
+	g.printf("}\n\n")
+}
+
+func (g *generator) printSummary(summary string) {
+	summary = strings.Join(strings.Fields(summary), " ")
+	if summary == "" {
+		g.printf("\n")
+		return
+	}
+
+	g.printf("\n//\n// %v%v.\n", strings.ToUpper(summary[:1]), summary[1:])
+}
+
+type param struct {
+	name   string
+	typ    string
+	decode string
+	encode string
+}
+
+func (g *generator) requestParams(iface *Interface, msg Message) []param {
+	var params []param
+	arg := func() string {
+		return fmt.Sprintf("args[%v]", len(params))
+	}
+
+	for _, a := range msg.Args {
+		pname := paramName(a.Name)
+		switch a.Type {
+		case "int", "uint":
+			base := "int32"
+			if a.Type == "uint" {
+				base = "uint32"
+			}
+			if enum := g.enumType(iface, a.Enum); enum != "" {
+				params = append(params, param{name: pname, typ: enum, decode: fmt.Sprintf("%v(%v.(%v))", enum, arg(), base)})
+				continue
+			}
+			params = append(params, param{name: pname, typ: base, decode: fmt.Sprintf("%v.(%v)", arg(), base)})
+		case "fixed":
+			params = append(params, param{name: pname, typ: "float64", decode: arg() + ".(float64)"})
+		case "string":
+			params = append(params, param{name: pname, typ: "string", decode: arg() + ".(string)"})
+		case "object":
+			if local, ok := g.local[a.Interface]; ok {
+				typ := camel(local.Name)
+				params = append(params, param{name: pname, typ: typ, decode: fmt.Sprintf("%v{%v.(wlr.Resource)}", typ, arg())})
+				continue
+			}
+			params = append(params, param{name: pname, typ: "wlr.Resource", decode: arg() + ".(wlr.Resource)"})
+		case "new_id":
+			if a.Interface == "" {
+				params = append(params, param{name: pname + "Interface", typ: "string", decode: arg() + ".(string)"})
+				params = append(params, param{name: pname + "Version", typ: "uint32", decode: arg() + ".(uint32)"})
+			}
+			params = append(params, param{name: pname, typ: "wlr.NewID", decode: arg() + ".(wlr.NewID)"})
+		case "array":
+			params = append(params, param{name: pname, typ: "[]byte", decode: arg() + ".([]byte)"})
+		case "fd":
+			params = append(params, param{name: pname, typ: "wlr.Fd", decode: arg() + ".(wlr.Fd)"})
+		}
+	}
+
+	return params
+}
+
+func (g *generator) eventParams(iface *Interface, msg Message) []param {
+	var params []param
+	for _, a := range msg.Args {
+		pname := paramName(a.Name)
+		switch a.Type {
+		case "int", "uint":
+			base := "int32"
+			if a.Type == "uint" {
+				base = "uint32"
+			}
+			if enum := g.enumType(iface, a.Enum); enum != "" {
+				params = append(params, param{name: pname, typ: enum, encode: fmt.Sprintf("%v(%v)", base, pname)})
+				continue
+			}
+			params = append(params, param{name: pname, typ: base, encode: pname})
+		case "fixed":
+			params = append(params, param{name: pname, typ: "float64", encode: pname})
+		case "string":
+			params = append(params, param{name: pname, typ: "string", encode: pname})
+		case "object", "new_id":
+			if a.Type == "new_id" && a.Interface == "" {
+				params = append(params, param{name: pname + "Interface", typ: "string", encode: pname + "Interface"})
+				params = append(params, param{name: pname + "Version", typ: "uint32", encode: pname + "Version"})
+			}
+			if local, ok := g.local[a.Interface]; ok {
+				params = append(params, param{name: pname, typ: camel(local.Name), encode: pname + ".Resource"})
+				continue
+			}
+			params = append(params, param{name: pname, typ: "wlr.Resource", encode: pname})
+		case "array":
+			params = append(params, param{name: pname, typ: "[]byte", encode: pname})
+		case "fd":
+			params = append(params, param{name: pname, typ: "wlr.Fd", encode: pname})
+		}
+	}
+
+	return params
+}
+
+// enumType returns the Go type of the enum referred to by an enum
+// attribute or an empty string if it is not declared in the protocol.
+func (g *generator) enumType(iface *Interface, ref string) string {
+	if ref == "" {
+		return ""
+	}
+
+	ifaceName, enumName, ok := strings.Cut(ref, ".")
+	if ok {
+		iface, ok = g.local[ifaceName]
+		if !ok {
+			return ""
+		}
+	} else {
+		enumName = ifaceName
+	}
+
+	for _, enum := range iface.Enums {
+		if enum.Name == enumName {
+			return camel(iface.Name) + camel(enum.Name)
+		}
+	}
+	return ""
+}
+
+// interfaceRef returns an expression referring to the named
+// interface. Interfaces declared by the protocol have a variable of
+// their own, while those from other protocols are looked up by name so
+// that they aren't declared more than once in a package.
+func (g *generator) interfaceRef(name string) string {
+	if _, ok := g.local[name]; ok {
+		return camel(name) + "Interface"
+	}
+	return fmt.Sprintf("wlr.InterfaceByName(%q)", name)
+}
+
+// sinceVersion returns the name of the constant holding the version
+// that msg, a request or event of iface, was introduced in. The kind
+// is part of the name because a request and an event may share a
+// name.
+func sinceVersion(iface *Interface, kind string, msg Message) string {
+	return camel(iface.Name) + kind + camel(msg.Name) + "SinceVersion"
+}
+
+// camel converts a snake_case protocol name to an exported Go name.
+func camel(name string) string {
+	var str strings.Builder
+	for part := range strings.SplitSeq(name, "_") {
+		if part == "" {
+			continue
+		}
+		str.WriteString(strings.ToUpper(part[:1]))
+		str.WriteString(part[1:])
+	}
+	return str.String()
+}
+
+func lowerCamel(name string) string {
+	name = camel(name)
+	if name == "" {
+		return name
+	}
+	return strings.ToLower(name[:1]) + name[1:]
+}
+
+func paramName(name string) string {
+	name = lowerCamel(name)
+	if token.IsKeyword(name) || name == "r" {
+		return name + "_"
+	}
+	return name
+}
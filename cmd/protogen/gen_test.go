@@ -0,0 +1,94 @@
+package main
+
+import (
+	"bytes"
+	"flag"
+	"go/ast"
+	"go/importer"
+	"go/parser"
+	"go/token"
+	"go/types"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+var update = flag.Bool("update", false, "update golden files")
+
+// TestGenerate generates code for the protocols in testdata, compares
+// it to the golden files, and type-checks the results together as a
+// single package against a stub of deedles.dev/wlr.
+func TestGenerate(t *testing.T) {
+	fset := token.NewFileSet()
+
+	var files []*ast.File
+	for _, name := range []string{"panel", "dock"} {
+		input := filepath.Join("testdata", name+".xml")
+		golden := filepath.Join("testdata", name+".go.golden")
+
+		file, err := os.Open(input)
+		if err != nil {
+			t.Fatal(err)
+		}
+		out, err := generate(file, "proto", filepath.Base(input))
+		file.Close()
+		if err != nil {
+			t.Fatalf("generate %v: %v", input, err)
+		}
+
+		if *update {
+			err := os.WriteFile(golden, out, 0644)
+			if err != nil {
+				t.Fatal(err)
+			}
+		}
+		want, err := os.ReadFile(golden)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !bytes.Equal(out, want) {
+			t.Errorf("output for %v does not match %v; run go test -update to regenerate it", input, golden)
+		}
+
+		f, err := parser.ParseFile(fset, name+".go", out, 0)
+		if err != nil {
+			t.Fatalf("parse output for %v: %v", input, err)
+		}
+		files = append(files, f)
+	}
+
+	conf := types.Config{Importer: stubImporter(t, fset)}
+	_, err := conf.Check("proto", fset, files, nil)
+	if err != nil {
+		t.Fatalf("type-check output: %v", err)
+	}
+}
+
+type importerFunc func(path string) (*types.Package, error)
+
+func (f importerFunc) Import(path string) (*types.Package, error) {
+	return f(path)
+}
+
+// stubImporter returns an importer that resolves deedles.dev/wlr to
+// the stub in testdata/wlr, since the real package requires cgo.
+func stubImporter(t *testing.T, fset *token.FileSet) types.Importer {
+	f, err := parser.ParseFile(fset, filepath.Join("testdata", "wlr", "wlr.go"), nil, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var conf types.Config
+	wlr, err := conf.Check("deedles.dev/wlr", fset, []*ast.File{f}, nil)
+	if err != nil {
+		t.Fatalf("type-check wlr stub: %v", err)
+	}
+
+	std := importer.Default()
+	return importerFunc(func(path string) (*types.Package, error) {
+		if path == "deedles.dev/wlr" {
+			return wlr, nil
+		}
+		return std.Import(path)
+	})
+}
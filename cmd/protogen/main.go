@@ -0,0 +1,85 @@
+// protogen generates server-side Go bindings for a Wayland protocol
+// from its XML description. The generated code is built on top of
+// the Go-implemented resources in deedles.dev/wlr.
+//
+// Usage:
+//
+//	protogen [-pkg name] <protocol.xml> <output.go>
+//
+// It is intended to be used with go generate, in which case the
+// package name defaults to that of the file containing the directive:
+//
+//	//go:generate go run deedles.dev/wlr/cmd/protogen panel-v1.xml panel.go
+//
+// For each interface in the protocol, protogen generates a type
+// wrapping a Resource, a handler interface for its requests, a
+// constructor that dispatches requests to a handler, methods for
+// sending its events, and constants for its enums and message
+// versions.
+package main
+
+import (
+	"bytes"
+	"flag"
+	"fmt"
+	"go/format"
+	"io"
+	"log/slog"
+	"os"
+	"path/filepath"
+)
+
+func main() {
+	pkg := flag.String("pkg", os.Getenv("GOPACKAGE"), "package name of the generated code")
+	flag.Usage = func() {
+		fmt.Fprintf(flag.CommandLine.Output(), "Usage: %v [options] <protocol.xml> <output.go>\n", os.Args[0])
+		flag.PrintDefaults()
+	}
+	flag.Parse()
+
+	if flag.NArg() != 2 || *pkg == "" {
+		flag.Usage()
+		os.Exit(2)
+	}
+	input, output := flag.Arg(0), flag.Arg(1)
+
+	file, err := os.Open(input)
+	if err != nil {
+		slog.Error("open protocol", "file", input, "err", err)
+		os.Exit(1)
+	}
+	formatted, err := generate(file, *pkg, filepath.Base(input))
+	file.Close()
+	if err != nil {
+		slog.Error("generate", "file", input, "err", err)
+		os.Exit(1)
+	}
+
+	err = os.WriteFile(output, formatted, 0644)
+	if err != nil {
+		slog.Error("write output", "file", output, "err", err)
+		os.Exit(1)
+	}
+}
+
+// generate parses the protocol XML read from r and returns formatted
+// Go source for it in package pkg. Source is the name of the protocol
+// file, which is mentioned in the generated header.
+func generate(r io.Reader, pkg, source string) ([]byte, error) {
+	proto, err := parse(r)
+	if err != nil {
+		return nil, fmt.Errorf("parse protocol: %w", err)
+	}
+
+	var buf bytes.Buffer
+	err = newGenerator(&buf, proto).gen(pkg, source)
+	if err != nil {
+		return nil, err
+	}
+
+	formatted, err := format.Source(buf.Bytes())
+	if err != nil {
+		return nil, fmt.Errorf("format output: %w", err)
+	}
+	return formatted, nil
+}
@@ -0,0 +1,127 @@
+package main
+
+import (
+	"encoding/xml"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+type Protocol struct {
+	Name        string      `xml:"name,attr"`
+	Copyright   string      `xml:"copyright"`
+	Description Description `xml:"description"`
+	Interfaces  []Interface `xml:"interface"`
+}
+
+type Interface struct {
+	Name        string      `xml:"name,attr"`
+	Version     int         `xml:"version,attr"`
+	Description Description `xml:"description"`
+	Requests    []Message   `xml:"request"`
+	Events      []Message   `xml:"event"`
+	Enums       []Enum      `xml:"enum"`
+}
+
+type Message struct {
+	Name        string      `xml:"name,attr"`
+	Type        string      `xml:"type,attr"`
+	Since       int         `xml:"since,attr"`
+	Description Description `xml:"description"`
+	Args        []Arg       `xml:"arg"`
+}
+
+type Arg struct {
+	Name      string `xml:"name,attr"`
+	Type      string `xml:"type,attr"`
+	Summary   string `xml:"summary,attr"`
+	Interface string `xml:"interface,attr"`
+	AllowNull bool   `xml:"allow-null,attr"`
+	Enum      string `xml:"enum,attr"`
+}
+
+type Enum struct {
+	Name        string      `xml:"name,attr"`
+	Since       int         `xml:"since,attr"`
+	Bitfield    bool        `xml:"bitfield,attr"`
+	Description Description `xml:"description"`
+	Entries     []Entry     `xml:"entry"`
+}
+
+type Entry struct {
+	Name    string `xml:"name,attr"`
+	Value   string `xml:"value,attr"`
+	Summary string `xml:"summary,attr"`
+	Since   int    `xml:"since,attr"`
+}
+
+type Description struct {
+	Summary string `xml:"summary,attr"`
+	Text    string `xml:",chardata"`
+}
+
+func parse(r io.Reader) (*Protocol, error) {
+	var p Protocol
+	err := xml.NewDecoder(r).Decode(&p)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, iface := range p.Interfaces {
+		for _, enum := range iface.Enums {
+			for _, entry := range enum.Entries {
+				_, err := strconv.ParseUint(entry.Value, 0, 32)
+				if err != nil {
+					return nil, fmt.Errorf("%v.%v.%v: invalid value %q", iface.Name, enum.Name, entry.Name, entry.Value)
+				}
+			}
+		}
+	}
+
+	return &p, nil
+}
+
+// SinceVersion returns the version that the message was introduced
+// in.
+func (m Message) SinceVersion() int {
+	return max(m.Since, 1)
+}
+
+// Signature returns the wire protocol signature of the message.
+func (m Message) Signature() string {
+	var sig strings.Builder
+	if m.Since > 1 {
+		sig.WriteString(strconv.FormatInt(int64(m.Since), 10))
+	}
+
+	for _, arg := range m.Args {
+		if arg.AllowNull {
+			sig.WriteByte('?')
+		}
+
+		switch arg.Type {
+		case "int":
+			sig.WriteByte('i')
+		case "uint":
+			sig.WriteByte('u')
+		case "fixed":
+			sig.WriteByte('f')
+		case "string":
+			sig.WriteByte('s')
+		case "object":
+			sig.WriteByte('o')
+		case "new_id":
+			if arg.Interface == "" {
+				sig.WriteString("su")
+			}
+			sig.WriteByte('n')
+		case "array":
+			sig.WriteByte('a')
+		case "fd":
+			sig.WriteByte('h')
+		}
+	}
+
+	return sig.String()
+}
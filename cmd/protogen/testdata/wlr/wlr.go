@@ -0,0 +1,39 @@
+// Package wlr is a stub of the parts of deedles.dev/wlr that generated
+// code uses. It allows generated code to be type-checked without cgo.
+package wlr
+
+type Interface struct {
+	Name     string
+	Version  int
+	Requests []Message
+	Events   []Message
+}
+
+type Message struct {
+	Name      string
+	Signature string
+	Types     []*Interface
+}
+
+func InterfaceByName(name string) *Interface { return &Interface{Name: name} }
+
+type (
+	NewID uint32
+	Fd    int32
+)
+
+type Client struct{}
+
+type RequestFunc func(r Resource, opcode uint32, args []any)
+
+type Resource struct{ p *int }
+
+func CreateResource(client Client, iface *Interface, version, id uint32) Resource {
+	return Resource{}
+}
+
+func (r Resource) Valid() bool                                                { return r.p != nil }
+func (r Resource) Version() uint32                                            { return 0 }
+func (r Resource) Destroy()                                                   {}
+func (r Resource) SetDispatcher(dispatch RequestFunc, destroy func(Resource)) {}
+func (r Resource) PostEvent(opcode uint32, args ...any)                       {}
@@ -7,10 +7,13 @@ package wlr
 #include <wayland-server-core.h>
 #include <wlr/types/wlr_cursor.h>
 #include <wlr/types/wlr_pointer.h>
+#include <wlr/types/wlr_tablet_tool.h>
+#include <wlr/types/wlr_touch.h>
 */
 import "C"
 
 import (
+	"image"
 	"time"
 	"unsafe"
 )
@@ -20,6 +23,8 @@ type Cursor struct {
 }
 
 func CreateCursor() Cursor {
+	checkThread()
+
 	p := C.wlr_cursor_create()
 	return Cursor{p: p}
 }
@@ -52,6 +57,40 @@ func (c Cursor) WarpAbsolute(dev InputDevice, x float64, y float64) {
 	C.wlr_cursor_warp_absolute(c.p, dev.p, C.double(x), C.double(y))
 }
 
+// MapToOutput restricts the cursor to output. If output is invalid,
+// the restriction is removed.
+func (c Cursor) MapToOutput(output Output) {
+	C.wlr_cursor_map_to_output(c.p, output.p)
+}
+
+// MapInputToOutput maps the absolute coordinates of events from dev,
+// such as a touchscreen or tablet, to output instead of to the entire
+// output layout. If output is invalid, the mapping is removed.
+func (c Cursor) MapInputToOutput(dev InputDevice, output Output) {
+	C.wlr_cursor_map_input_to_output(c.p, dev.p, output.p)
+}
+
+// MapToRegion restricts the cursor to box, in layout coordinates. If
+// box is empty, the restriction is removed.
+func (c Cursor) MapToRegion(box image.Rectangle) {
+	C.wlr_cursor_map_to_region(c.p, boxToC(box))
+}
+
+// MapInputToRegion is like MapInputToOutput but maps dev to box, in
+// layout coordinates.
+func (c Cursor) MapInputToRegion(dev InputDevice, box image.Rectangle) {
+	C.wlr_cursor_map_input_to_region(c.p, dev.p, boxToC(box))
+}
+
+// AbsoluteToLayoutCoords converts the normalized absolute coordinates
+// of an event from dev, such as a touch point, to layout coordinates,
+// taking into account any mapping of dev to an output or region.
+func (c Cursor) AbsoluteToLayoutCoords(dev InputDevice, x, y float64) (lx, ly float64) {
+	var clx, cly C.double
+	C.wlr_cursor_absolute_to_layout_coords(c.p, dev.p, C.double(x), C.double(y), &clx, &cly)
+	return float64(clx), float64(cly)
+}
+
 func (c Cursor) SetSurface(surface Surface, hotspotX int32, hotspotY int32) {
 	C.wlr_cursor_set_surface(c.p, surface.p, C.int32_t(hotspotX), C.int32_t(hotspotY))
 }
@@ -71,6 +110,24 @@ func (c Cursor) OnMotion(cb func(p Pointer, time time.Time, dx, dy float64)) Lis
 	})
 }
 
+// OnRelativeMotion is like OnMotion but also provides the
+// unaccelerated deltas of the motion, as needed by
+// RelativePointerManagerV1.SendRelativeMotion.
+func (c Cursor) OnRelativeMotion(cb func(p Pointer, time time.Time, dx, dy, unaccelDX, unaccelDY float64)) Listener {
+	return newListener(&c.p.events.motion, func(lis Listener, data unsafe.Pointer) {
+		event := (*C.struct_wlr_pointer_motion_event)(data)
+		dev := Pointer{p: event.pointer}
+		cb(
+			dev,
+			time.UnixMilli(int64(event.time_msec)),
+			float64(event.delta_x),
+			float64(event.delta_y),
+			float64(event.unaccel_dx),
+			float64(event.unaccel_dy),
+		)
+	})
+}
+
 func (c Cursor) OnMotionAbsolute(cb func(p Pointer, time time.Time, x, y float64)) Listener {
 	return newListener(&c.p.events.motion_absolute, func(lis Listener, data unsafe.Pointer) {
 		event := (*C.struct_wlr_pointer_motion_absolute_event)(data)
@@ -108,6 +165,149 @@ func (c Cursor) OnFrame(cb func()) Listener {
 	})
 }
 
+func (c Cursor) OnSwipeBegin(cb func(p Pointer, time time.Time, fingers uint32)) Listener {
+	return newListener(&c.p.events.swipe_begin, func(lis Listener, data unsafe.Pointer) {
+		event := (*C.struct_wlr_pointer_swipe_begin_event)(data)
+		dev := Pointer{p: event.pointer}
+		cb(dev, time.UnixMilli(int64(event.time_msec)), uint32(event.fingers))
+	})
+}
+
+func (c Cursor) OnSwipeUpdate(cb func(p Pointer, time time.Time, fingers uint32, dx, dy float64)) Listener {
+	return newListener(&c.p.events.swipe_update, func(lis Listener, data unsafe.Pointer) {
+		event := (*C.struct_wlr_pointer_swipe_update_event)(data)
+		dev := Pointer{p: event.pointer}
+		cb(dev, time.UnixMilli(int64(event.time_msec)), uint32(event.fingers), float64(event.dx), float64(event.dy))
+	})
+}
+
+func (c Cursor) OnSwipeEnd(cb func(p Pointer, time time.Time, cancelled bool)) Listener {
+	return newListener(&c.p.events.swipe_end, func(lis Listener, data unsafe.Pointer) {
+		event := (*C.struct_wlr_pointer_swipe_end_event)(data)
+		dev := Pointer{p: event.pointer}
+		cb(dev, time.UnixMilli(int64(event.time_msec)), bool(event.cancelled))
+	})
+}
+
+func (c Cursor) OnPinchBegin(cb func(p Pointer, time time.Time, fingers uint32)) Listener {
+	return newListener(&c.p.events.pinch_begin, func(lis Listener, data unsafe.Pointer) {
+		event := (*C.struct_wlr_pointer_pinch_begin_event)(data)
+		dev := Pointer{p: event.pointer}
+		cb(dev, time.UnixMilli(int64(event.time_msec)), uint32(event.fingers))
+	})
+}
+
+// OnPinchUpdate is called as a pinch gesture progresses. Scale is
+// relative to the start of the gesture and rotation is the change in
+// degrees since the previous update.
+func (c Cursor) OnPinchUpdate(cb func(p Pointer, time time.Time, fingers uint32, dx, dy, scale, rotation float64)) Listener {
+	return newListener(&c.p.events.pinch_update, func(lis Listener, data unsafe.Pointer) {
+		event := (*C.struct_wlr_pointer_pinch_update_event)(data)
+		dev := Pointer{p: event.pointer}
+		cb(
+			dev,
+			time.UnixMilli(int64(event.time_msec)),
+			uint32(event.fingers),
+			float64(event.dx),
+			float64(event.dy),
+			float64(event.scale),
+			float64(event.rotation),
+		)
+	})
+}
+
+func (c Cursor) OnPinchEnd(cb func(p Pointer, time time.Time, cancelled bool)) Listener {
+	return newListener(&c.p.events.pinch_end, func(lis Listener, data unsafe.Pointer) {
+		event := (*C.struct_wlr_pointer_pinch_end_event)(data)
+		dev := Pointer{p: event.pointer}
+		cb(dev, time.UnixMilli(int64(event.time_msec)), bool(event.cancelled))
+	})
+}
+
+func (c Cursor) OnHoldBegin(cb func(p Pointer, time time.Time, fingers uint32)) Listener {
+	return newListener(&c.p.events.hold_begin, func(lis Listener, data unsafe.Pointer) {
+		event := (*C.struct_wlr_pointer_hold_begin_event)(data)
+		dev := Pointer{p: event.pointer}
+		cb(dev, time.UnixMilli(int64(event.time_msec)), uint32(event.fingers))
+	})
+}
+
+func (c Cursor) OnHoldEnd(cb func(p Pointer, time time.Time, cancelled bool)) Listener {
+	return newListener(&c.p.events.hold_end, func(lis Listener, data unsafe.Pointer) {
+		event := (*C.struct_wlr_pointer_hold_end_event)(data)
+		dev := Pointer{p: event.pointer}
+		cb(dev, time.UnixMilli(int64(event.time_msec)), bool(event.cancelled))
+	})
+}
+
+// OnTouchDown is called when a touch point is added by a touch device
+// attached to the cursor. The coordinates are normalized to the range
+// [0, 1] and can be converted with AbsoluteToLayoutCoords.
+func (c Cursor) OnTouchDown(cb func(t Touch, time time.Time, touchID int32, x, y float64)) Listener {
+	return newListener(&c.p.events.touch_down, func(lis Listener, data unsafe.Pointer) {
+		event := (*C.struct_wlr_touch_down_event)(data)
+		dev := Touch{p: event.touch}
+		cb(dev, time.UnixMilli(int64(event.time_msec)), int32(event.touch_id), float64(event.x), float64(event.y))
+	})
+}
+
+func (c Cursor) OnTouchUp(cb func(t Touch, time time.Time, touchID int32)) Listener {
+	return newListener(&c.p.events.touch_up, func(lis Listener, data unsafe.Pointer) {
+		event := (*C.struct_wlr_touch_up_event)(data)
+		dev := Touch{p: event.touch}
+		cb(dev, time.UnixMilli(int64(event.time_msec)), int32(event.touch_id))
+	})
+}
+
+func (c Cursor) OnTouchMotion(cb func(t Touch, time time.Time, touchID int32, x, y float64)) Listener {
+	return newListener(&c.p.events.touch_motion, func(lis Listener, data unsafe.Pointer) {
+		event := (*C.struct_wlr_touch_motion_event)(data)
+		dev := Touch{p: event.touch}
+		cb(dev, time.UnixMilli(int64(event.time_msec)), int32(event.touch_id), float64(event.x), float64(event.y))
+	})
+}
+
+func (c Cursor) OnTouchCancel(cb func(t Touch, time time.Time, touchID int32)) Listener {
+	return newListener(&c.p.events.touch_cancel, func(lis Listener, data unsafe.Pointer) {
+		event := (*C.struct_wlr_touch_cancel_event)(data)
+		dev := Touch{p: event.touch}
+		cb(dev, time.UnixMilli(int64(event.time_msec)), int32(event.touch_id))
+	})
+}
+
+func (c Cursor) OnTouchFrame(cb func()) Listener {
+	return newListener(&c.p.events.touch_frame, func(lis Listener, data unsafe.Pointer) {
+		cb()
+	})
+}
+
+// OnTabletToolAxis is called when the axes of a tool on a tablet
+// attached to the cursor change. The cursor is not moved
+// automatically.
+func (c Cursor) OnTabletToolAxis(cb func(TabletToolAxisEvent)) Listener {
+	return newListener(&c.p.events.tablet_tool_axis, func(lis Listener, data unsafe.Pointer) {
+		cb(tabletToolAxisEventFromC((*C.struct_wlr_tablet_tool_axis_event)(data)))
+	})
+}
+
+func (c Cursor) OnTabletToolProximity(cb func(TabletToolProximityEvent)) Listener {
+	return newListener(&c.p.events.tablet_tool_proximity, func(lis Listener, data unsafe.Pointer) {
+		cb(tabletToolProximityEventFromC((*C.struct_wlr_tablet_tool_proximity_event)(data)))
+	})
+}
+
+func (c Cursor) OnTabletToolTip(cb func(TabletToolTipEvent)) Listener {
+	return newListener(&c.p.events.tablet_tool_tip, func(lis Listener, data unsafe.Pointer) {
+		cb(tabletToolTipEventFromC((*C.struct_wlr_tablet_tool_tip_event)(data)))
+	})
+}
+
+func (c Cursor) OnTabletToolButton(cb func(TabletToolButtonEvent)) Listener {
+	return newListener(&c.p.events.tablet_tool_button, func(lis Listener, data unsafe.Pointer) {
+		cb(tabletToolButtonEventFromC((*C.struct_wlr_tablet_tool_button_event)(data)))
+	})
+}
+
 type CursorButton uint32
 
 const (
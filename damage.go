@@ -0,0 +1,95 @@
+package wlr
+
+/*
+#include <stdlib.h>
+#include <wlr/types/wlr_damage_ring.h>
+*/
+import "C"
+
+import (
+	"image"
+	"unsafe"
+)
+
+// DamageRing accumulates damage for an output across frames so that
+// only the parts of a buffer that have changed since it was last
+// drawn to need to be redrawn.
+//
+// A typical frame handler adds damage as surfaces commit, gets the
+// damage for the next buffer from its age with BufferDamage, redraws
+// only that area, commits, and then calls Rotate.
+type DamageRing struct {
+	p *C.struct_wlr_damage_ring
+}
+
+func CreateDamageRing() DamageRing {
+	checkThread()
+
+	p := (*C.struct_wlr_damage_ring)(C.malloc(C.sizeof_struct_wlr_damage_ring))
+	C.wlr_damage_ring_init(p)
+	return DamageRing{p: p}
+}
+
+func (r DamageRing) Valid() bool {
+	return r.p != nil
+}
+
+func (r DamageRing) Destroy() {
+	C.wlr_damage_ring_finish(r.p)
+	C.free(unsafe.Pointer(r.p))
+}
+
+// SetBounds sets the size of the area being tracked, usually the
+// transformed resolution of an output. Changing the bounds damages
+// the entire area.
+func (r DamageRing) SetBounds(width, height int) {
+	C.wlr_damage_ring_set_bounds(r.p, C.int32_t(width), C.int32_t(height))
+}
+
+// Current returns the damage accumulated since the last call to
+// Rotate. The returned Region belongs to the ring and must not be
+// destroyed.
+func (r DamageRing) Current() Region {
+	return Region{p: &r.p.current}
+}
+
+// Add adds damage to the ring. It returns false if none of the damage
+// was inside of the ring's bounds. The zero Region is treated as
+// empty.
+func (r DamageRing) Add(damage Region) bool {
+	if damage.Empty() {
+		return false
+	}
+
+	return bool(C.wlr_damage_ring_add(r.p, damage.p))
+}
+
+// AddBox adds box to the ring's damage. It returns false if box was
+// not inside of the ring's bounds.
+func (r DamageRing) AddBox(box image.Rectangle) bool {
+	if box.Empty() {
+		return false
+	}
+
+	return bool(C.wlr_damage_ring_add_box(r.p, boxToC(box)))
+}
+
+// AddWhole damages the ring's entire bounds.
+func (r DamageRing) AddWhole() {
+	C.wlr_damage_ring_add_whole(r.p)
+}
+
+// Rotate moves the current damage into the ring's history. It should
+// be called after each frame has been committed.
+func (r DamageRing) Rotate() {
+	C.wlr_damage_ring_rotate(r.p)
+}
+
+// BufferDamage stores in damage the area that needs to be redrawn in
+// a buffer with the given age, as returned by Output.AttachRender.
+// Buffers that are too old or have an age of zero are considered
+// entirely damaged. It panics if damage is the zero Region.
+func (r DamageRing) BufferDamage(bufferAge int, damage Region) {
+	damage.mustBeValid()
+	C.wlr_damage_ring_get_buffer_damage(r.p, C.int(bufferAge), damage.p)
+}
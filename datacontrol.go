@@ -12,6 +12,8 @@ type DataControlManagerV1 struct {
 }
 
 func CreateDataControlManagerV1(display Display) DataControlManagerV1 {
+	checkThread()
+
 	p := C.wlr_data_control_manager_v1_create(display.p)
 	return DataControlManagerV1{p: p}
 }
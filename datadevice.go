@@ -0,0 +1,513 @@
+package wlr
+
+/*
+#include <stdlib.h>
+#include <wlr/types/wlr_data_device.h>
+#include <wlr/types/wlr_seat.h>
+
+struct _go_data_source {
+	struct wlr_data_source base;
+	uintptr_t handle;
+};
+
+extern void _data_source_send(struct wlr_data_source *source, char *mime_type, int32_t fd);
+extern void _data_source_accept(struct wlr_data_source *source, uint32_t serial, char *mime_type);
+extern void _data_source_destroy(struct wlr_data_source *source);
+extern void _data_source_dnd_drop(struct wlr_data_source *source);
+extern void _data_source_dnd_finish(struct wlr_data_source *source);
+extern void _data_source_dnd_action(struct wlr_data_source *source, uint32_t action);
+
+static void _data_source_send_cb(struct wlr_data_source *source, const char *mime_type, int32_t fd) {
+	_data_source_send(source, (char *)mime_type, fd);
+}
+
+static void _data_source_accept_cb(struct wlr_data_source *source, uint32_t serial, const char *mime_type) {
+	_data_source_accept(source, serial, (char *)mime_type);
+}
+
+static void _data_source_dnd_action_cb(struct wlr_data_source *source, enum wl_data_device_manager_dnd_action action) {
+	_data_source_dnd_action(source, action);
+}
+
+static const struct wlr_data_source_impl _data_source_impl = {
+	.send = _data_source_send_cb,
+	.destroy = _data_source_destroy,
+};
+
+static const struct wlr_data_source_impl _data_source_dnd_impl = {
+	.send = _data_source_send_cb,
+	.accept = _data_source_accept_cb,
+	.destroy = _data_source_destroy,
+	.dnd_drop = _data_source_dnd_drop,
+	.dnd_finish = _data_source_dnd_finish,
+	.dnd_action = _data_source_dnd_action_cb,
+};
+
+static inline struct wlr_data_source *_new_data_source(uintptr_t handle, int dnd) {
+	struct _go_data_source *source = calloc(1, sizeof(*source));
+	wlr_data_source_init(&source->base, dnd ? &_data_source_dnd_impl : &_data_source_impl);
+	source->handle = handle;
+	return &source->base;
+}
+
+static inline uintptr_t _data_source_handle(struct wlr_data_source *source) {
+	return ((struct _go_data_source *)source)->handle;
+}
+
+static inline void _data_source_add_mime_type(struct wlr_data_source *source, char *mime_type) {
+	char **p = wl_array_add(&source->mime_types, sizeof(*p));
+	if (p == NULL) {
+		free(mime_type);
+		return;
+	}
+	*p = mime_type;
+}
+*/
+import "C"
+
+import (
+	"runtime/cgo"
+	"time"
+	"unsafe"
+)
+
+type DataDeviceManager struct {
+	p *C.struct_wlr_data_device_manager
+}
+
+func CreateDataDeviceManager(display Display) DataDeviceManager {
+	checkThread()
+
+	p := C.wlr_data_device_manager_create(display.p)
+	return DataDeviceManager{p: p}
+}
+
+func (m DataDeviceManager) OnDestroy(cb func(DataDeviceManager)) Listener {
+	return newListener(&m.p.events.destroy, func(lis Listener, data unsafe.Pointer) {
+		cb(m)
+	})
+}
+
+// DnDAction is a set of actions that can be performed on the data of
+// a drag-and-drop operation when it is dropped.
+type DnDAction uint32
+
+const (
+	DnDActionNone DnDAction = C.WL_DATA_DEVICE_MANAGER_DND_ACTION_NONE
+	DnDActionCopy DnDAction = C.WL_DATA_DEVICE_MANAGER_DND_ACTION_COPY
+	DnDActionMove DnDAction = C.WL_DATA_DEVICE_MANAGER_DND_ACTION_MOVE
+	DnDActionAsk  DnDAction = C.WL_DATA_DEVICE_MANAGER_DND_ACTION_ASK
+)
+
+// DataSource offers data in one or more MIME types, either as the
+// selection of a seat or as the source of a drag-and-drop operation.
+// Sources are usually created by clients, but a compositor can offer
+// its own data with CreateDataSource.
+type DataSource struct {
+	p *C.struct_wlr_data_source
+}
+
+// DataSourceImpl implements a compositor-owned DataSource.
+type DataSourceImpl interface {
+	// Send writes the data of the source in the given MIME type to fd
+	// and then closes it. The receiver may read slowly, so writing
+	// should usually be done from another goroutine.
+	Send(source DataSource, mimeType string, fd Fd)
+
+	// Destroy is called when the source is destroyed, such as when it
+	// is replaced as the selection. The source must not be used after
+	// it returns.
+	Destroy(source DataSource)
+}
+
+// DataSourceDnDImpl may be implemented in addition to DataSourceImpl
+// by sources that are used for drag-and-drop.
+type DataSourceDnDImpl interface {
+	DataSourceImpl
+
+	// Accept is called when the target of a drag accepts a MIME type.
+	// The MIME type is empty if the target does not accept any of the
+	// offered types.
+	Accept(source DataSource, serial uint32, mimeType string)
+
+	// DnDDrop is called when the drag is dropped onto a target that
+	// accepted it. The transfer has not necessarily finished yet.
+	DnDDrop(source DataSource)
+
+	// DnDFinish is called when the target has finished with the data
+	// after a drop. It is followed by a call to Destroy.
+	DnDFinish(source DataSource)
+
+	// DnDAction is called when the action negotiated between the
+	// source and the target changes.
+	DnDAction(source DataSource, action DnDAction)
+}
+
+// CreateDataSource creates a DataSource that offers the given MIME
+// types and is implemented by impl. If impl implements
+// DataSourceDnDImpl, the source can also be used for drag-and-drop.
+func CreateDataSource(impl DataSourceImpl, mimeTypes []string) DataSource {
+	checkThread()
+
+	_, dnd := impl.(DataSourceDnDImpl)
+	handle := cgo.NewHandle(impl)
+
+	var cdnd C.int
+	if dnd {
+		cdnd = 1
+	}
+	p := C._new_data_source(C.uintptr_t(handle), cdnd)
+	for _, t := range mimeTypes {
+		C._data_source_add_mime_type(p, C.CString(t))
+	}
+
+	return DataSource{p: p}
+}
+
+func (s DataSource) Valid() bool {
+	return s.p != nil
+}
+
+func (s DataSource) OnDestroy(cb func(DataSource)) Listener {
+	return newListener(&s.p.events.destroy, func(lis Listener, data unsafe.Pointer) {
+		cb(s)
+	})
+}
+
+// Destroy destroys the source. If it is a client's source, the client
+// is notified that it has been cancelled.
+func (s DataSource) Destroy() {
+	C.wlr_data_source_destroy(s.p)
+}
+
+// MimeTypes returns the MIME types that the source offers.
+func (s DataSource) MimeTypes() []string {
+	return wlArrayStrings(&s.p.mime_types)
+}
+
+// Actions returns the drag-and-drop actions that the source supports.
+// It returns DnDActionNone if the source has not set any, in which
+// case a drag behaves as if only DnDActionCopy was supported.
+func (s DataSource) Actions() DnDAction {
+	if s.p.actions < 0 {
+		return DnDActionNone
+	}
+
+	return DnDAction(s.p.actions)
+}
+
+// SetActions sets the drag-and-drop actions that a source created by
+// CreateDataSource supports. It should be called before the source is
+// used to start a drag.
+func (s DataSource) SetActions(actions DnDAction) {
+	checkThread()
+
+	s.p.actions = C.int32_t(actions)
+}
+
+// CurrentDnDAction returns the action that has been negotiated between
+// the source and the target of a drag.
+func (s DataSource) CurrentDnDAction() DnDAction {
+	return DnDAction(s.p.current_dnd_action)
+}
+
+// Send requests that the source write its data in the given MIME type
+// to fd. Ownership of fd is transferred to the source.
+func (s DataSource) Send(mimeType string, fd Fd) {
+	checkThread()
+
+	cmime := C.CString(mimeType)
+	defer C.free(unsafe.Pointer(cmime))
+
+	C.wlr_data_source_send(s.p, cmime, C.int32_t(fd))
+}
+
+// Accept notifies the source that the target of a drag accepts
+// mimeType. An empty mimeType means that no type is accepted.
+func (s DataSource) Accept(serial uint32, mimeType string) {
+	checkThread()
+
+	var cmime *C.char
+	if mimeType != "" {
+		cmime = C.CString(mimeType)
+		defer C.free(unsafe.Pointer(cmime))
+	}
+
+	C.wlr_data_source_accept(s.p, C.uint32_t(serial), cmime)
+}
+
+func (s DataSource) DnDDrop() {
+	checkThread()
+
+	C.wlr_data_source_dnd_drop(s.p)
+}
+
+func (s DataSource) DnDFinish() {
+	checkThread()
+
+	C.wlr_data_source_dnd_finish(s.p)
+}
+
+func (s DataSource) DnDAction(action DnDAction) {
+	checkThread()
+
+	C.wlr_data_source_dnd_action(s.p, C.enum_wl_data_device_manager_dnd_action(action))
+}
+
+func dataSourceImplFromC(source *C.struct_wlr_data_source) DataSourceImpl {
+	return cgo.Handle(C._data_source_handle(source)).Value().(DataSourceImpl)
+}
+
+//export _data_source_send
+func _data_source_send(source *C.struct_wlr_data_source, mimeType *C.char, fd C.int32_t) {
+	dataSourceImplFromC(source).Send(DataSource{p: source}, C.GoString(mimeType), Fd(fd))
+}
+
+//export _data_source_accept
+func _data_source_accept(source *C.struct_wlr_data_source, serial C.uint32_t, mimeType *C.char) {
+	impl := dataSourceImplFromC(source).(DataSourceDnDImpl)
+	impl.Accept(DataSource{p: source}, uint32(serial), C.GoString(mimeType))
+}
+
+//export _data_source_destroy
+func _data_source_destroy(source *C.struct_wlr_data_source) {
+	handle := cgo.Handle(C._data_source_handle(source))
+	defer C.free(unsafe.Pointer(source))
+	defer handle.Delete()
+
+	handle.Value().(DataSourceImpl).Destroy(DataSource{p: source})
+}
+
+//export _data_source_dnd_drop
+func _data_source_dnd_drop(source *C.struct_wlr_data_source) {
+	impl := dataSourceImplFromC(source).(DataSourceDnDImpl)
+	impl.DnDDrop(DataSource{p: source})
+}
+
+//export _data_source_dnd_finish
+func _data_source_dnd_finish(source *C.struct_wlr_data_source) {
+	impl := dataSourceImplFromC(source).(DataSourceDnDImpl)
+	impl.DnDFinish(DataSource{p: source})
+}
+
+//export _data_source_dnd_action
+func _data_source_dnd_action(source *C.struct_wlr_data_source, action C.uint32_t) {
+	impl := dataSourceImplFromC(source).(DataSourceDnDImpl)
+	impl.DnDAction(DataSource{p: source}, DnDAction(action))
+}
+
+type DragGrabType uint32
+
+const (
+	DragGrabKeyboard        DragGrabType = C.WLR_DRAG_GRAB_KEYBOARD
+	DragGrabKeyboardPointer DragGrabType = C.WLR_DRAG_GRAB_KEYBOARD_POINTER
+	DragGrabKeyboardTouch   DragGrabType = C.WLR_DRAG_GRAB_KEYBOARD_TOUCH
+)
+
+// Drag is a drag-and-drop operation.
+type Drag struct {
+	p *C.struct_wlr_drag
+}
+
+// CreateDrag creates a drag on behalf of client. Source and icon may
+// be invalid. The drag does not begin until it is passed to one of
+// the Seat's StartDrag methods.
+func CreateDrag(client SeatClient, source DataSource, icon Surface) Drag {
+	checkThread()
+
+	p := C.wlr_drag_create(client.p, source.p, icon.p)
+	return Drag{p: p}
+}
+
+func (d Drag) Valid() bool {
+	return d.p != nil
+}
+
+func (d Drag) GrabType() DragGrabType {
+	return DragGrabType(d.p.grab_type)
+}
+
+func (d Drag) Seat() Seat {
+	return Seat{p: d.p.seat}
+}
+
+// Source returns the data being dragged. It is invalid if the drag is
+// only within the client that started it.
+func (d Drag) Source() DataSource {
+	return DataSource{p: d.p.source}
+}
+
+// Icon returns the icon that should be drawn under the cursor during
+// the drag, if any.
+func (d Drag) Icon() DragIcon {
+	return DragIcon{p: d.p.icon}
+}
+
+// Focus returns the surface that the drag is currently over, if any.
+func (d Drag) Focus() Surface {
+	return Surface{p: d.p.focus}
+}
+
+func (d Drag) OnFocus(cb func(Drag)) Listener {
+	return newListener(&d.p.events.focus, func(lis Listener, data unsafe.Pointer) {
+		cb(d)
+	})
+}
+
+func (d Drag) OnMotion(cb func(drag Drag, time time.Time, sx, sy float64)) Listener {
+	return newListener(&d.p.events.motion, func(lis Listener, data unsafe.Pointer) {
+		event := (*C.struct_wlr_drag_motion_event)(data)
+		cb(d, time.UnixMilli(int64(event.time)), float64(event.sx), float64(event.sy))
+	})
+}
+
+func (d Drag) OnDrop(cb func(drag Drag, time time.Time)) Listener {
+	return newListener(&d.p.events.drop, func(lis Listener, data unsafe.Pointer) {
+		event := (*C.struct_wlr_drag_drop_event)(data)
+		cb(d, time.UnixMilli(int64(event.time)))
+	})
+}
+
+func (d Drag) OnDestroy(cb func(Drag)) Listener {
+	return newListener(&d.p.events.destroy, func(lis Listener, data unsafe.Pointer) {
+		cb(d)
+	})
+}
+
+// DragIcon is a surface that is drawn under the cursor during a drag.
+// The compositor is responsible for drawing it, such as by adding it
+// to a scene with CreateSceneSubsurfaceTree.
+type DragIcon struct {
+	p *C.struct_wlr_drag_icon
+}
+
+func (i DragIcon) Valid() bool {
+	return i.p != nil
+}
+
+func (i DragIcon) Drag() Drag {
+	return Drag{p: i.p.drag}
+}
+
+func (i DragIcon) Surface() Surface {
+	return Surface{p: i.p.surface}
+}
+
+func (i DragIcon) OnDestroy(cb func(DragIcon)) Listener {
+	return newListener(&i.p.events.destroy, func(lis Listener, data unsafe.Pointer) {
+		cb(i)
+	})
+}
+
+// OnMap is a convenience method that listens for the icon's surface
+// being mapped.
+func (i DragIcon) OnMap(cb func(DragIcon)) Listener {
+	return i.Surface().OnMap(func(Surface) { cb(i) })
+}
+
+// OnUnmap is a convenience method that listens for the icon's surface
+// being unmapped.
+func (i DragIcon) OnUnmap(cb func(DragIcon)) Listener {
+	return i.Surface().OnUnmap(func(Surface) { cb(i) })
+}
+
+// OnRequestSetSelection is called when a client asks to set the
+// selection. The compositor should usually call SetSelection with the
+// same arguments to allow it.
+func (s Seat) OnRequestSetSelection(cb func(source DataSource, serial uint32)) Listener {
+	return newListener(&s.p.events.request_set_selection, func(lis Listener, data unsafe.Pointer) {
+		event := (*C.struct_wlr_seat_request_set_selection_event)(data)
+		cb(DataSource{p: event.source}, uint32(event.serial))
+	})
+}
+
+// OnSetSelection is called after the seat's selection has changed.
+func (s Seat) OnSetSelection(cb func(Seat)) Listener {
+	return newListener(&s.p.events.set_selection, func(lis Listener, data unsafe.Pointer) {
+		cb(s)
+	})
+}
+
+// SetSelection sets the seat's selection, destroying the previous
+// selection source, if any. An invalid source clears the selection.
+func (s Seat) SetSelection(source DataSource, serial uint32) {
+	checkThread()
+
+	C.wlr_seat_set_selection(s.p, source.p, C.uint32_t(serial))
+}
+
+// RequestSetSelection emits the request_set_selection event as if
+// client had requested it, letting the compositor's usual handler
+// decide whether to allow it.
+func (s Seat) RequestSetSelection(client SeatClient, source DataSource, serial uint32) {
+	checkThread()
+
+	C.wlr_seat_request_set_selection(s.p, client.p, source.p, C.uint32_t(serial))
+}
+
+// Selection returns the current selection source of the seat, if any.
+func (s Seat) Selection() DataSource {
+	return DataSource{p: s.p.selection_source}
+}
+
+// OnRequestStartDrag is called when a client asks to start a drag
+// from origin. The compositor should validate serial, usually with
+// ValidatePointerGrabSerial or ValidateTouchGrabSerial, and then start
+// the drag with StartPointerDrag or StartTouchDrag, or otherwise
+// destroy its source.
+func (s Seat) OnRequestStartDrag(cb func(drag Drag, origin Surface, serial uint32)) Listener {
+	return newListener(&s.p.events.request_start_drag, func(lis Listener, data unsafe.Pointer) {
+		event := (*C.struct_wlr_seat_request_start_drag_event)(data)
+		cb(Drag{p: event.drag}, Surface{p: event.origin}, uint32(event.serial))
+	})
+}
+
+// OnStartDrag is called when a drag begins. If the drag has an icon,
+// this is the time to start drawing it.
+func (s Seat) OnStartDrag(cb func(Drag)) Listener {
+	return newListener(&s.p.events.start_drag, func(lis Listener, data unsafe.Pointer) {
+		cb(Drag{p: (*C.struct_wlr_drag)(data)})
+	})
+}
+
+// StartDrag starts a drag that is controlled only by the keyboard.
+func (s Seat) StartDrag(drag Drag, serial uint32) {
+	checkThread()
+
+	C.wlr_seat_start_drag(s.p, drag.p, C.uint32_t(serial))
+}
+
+// StartPointerDrag starts a drag that follows the pointer and ends
+// when its button is released.
+func (s Seat) StartPointerDrag(drag Drag, serial uint32) {
+	checkThread()
+
+	C.wlr_seat_start_pointer_drag(s.p, drag.p, C.uint32_t(serial))
+}
+
+// StartTouchDrag starts a drag that follows point and ends when it is
+// lifted.
+func (s Seat) StartTouchDrag(drag Drag, serial uint32, point TouchPoint) {
+	checkThread()
+
+	C.wlr_seat_start_touch_drag(s.p, drag.p, C.uint32_t(serial), point.p)
+}
+
+// Drag returns the seat's current drag, if any.
+func (s Seat) Drag() Drag {
+	return Drag{p: s.p.drag}
+}
+
+// ValidatePointerGrabSerial returns true if serial is that of the
+// pointer button press that is currently held on origin.
+func (s Seat) ValidatePointerGrabSerial(origin Surface, serial uint32) bool {
+	return bool(C.wlr_seat_validate_pointer_grab_serial(s.p, origin.p, C.uint32_t(serial)))
+}
+
+// ValidateTouchGrabSerial is like ValidatePointerGrabSerial but for a
+// touch point that is down on origin, which it returns.
+func (s Seat) ValidateTouchGrabSerial(origin Surface, serial uint32) (TouchPoint, bool) {
+	var point *C.struct_wlr_touch_point
+	ok := C.wlr_seat_validate_touch_grab_serial(s.p, origin.p, C.uint32_t(serial), &point)
+	return TouchPoint{p: point}, bool(ok)
+}
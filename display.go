@@ -15,8 +15,14 @@ type Display struct {
 }
 
 func CreateDisplay() Display {
+	checkThread()
+
 	p := C.wl_display_create()
-	return Display{p: p}
+	d := Display{p: p}
+	if p != nil {
+		attachInvokeQueue(d.EventLoop())
+	}
+	return d
 }
 
 func (d Display) Destroy() {
@@ -32,6 +38,8 @@ func (d Display) OnDestroy(cb func(Display)) Listener {
 }
 
 func (d Display) Run() {
+	defer enterLoop(d.EventLoop())()
+
 	C.wl_display_run(d.p)
 }
 
@@ -13,6 +13,8 @@ type LinuxDMABufV1 struct {
 }
 
 func CreateLinuxDMABufV1WithRenderer(display Display, version uint32, renderer Renderer) LinuxDMABufV1 {
+	checkThread()
+
 	p := C.wlr_linux_dmabuf_v1_create_with_renderer(display.p, C.uint32_t(version), renderer.p)
 	return LinuxDMABufV1{p: p}
 }
@@ -28,6 +30,8 @@ type ExportDMABufManagerV1 struct {
 }
 
 func CreateExportDMABufV1(display Display) ExportDMABufManagerV1 {
+	checkThread()
+
 	p := C.wlr_export_dmabuf_manager_v1_create(display.p)
 	return ExportDMABufManagerV1{p: p}
 }
@@ -11,6 +11,8 @@ type DRM struct {
 }
 
 func CreateDRM(d Display, r Renderer) DRM {
+	checkThread()
+
 	p := C.wlr_drm_create(d.p, r.p)
 	return DRM{p: p}
 }
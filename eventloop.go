@@ -0,0 +1,231 @@
+package wlr
+
+/*
+#include <wayland-server-core.h>
+
+extern int _event_loop_fd_cb(int fd, uint32_t mask, uintptr_t handle);
+extern int _event_loop_timer_cb(uintptr_t handle);
+extern int _event_loop_signal_cb(int signal_number, uintptr_t handle);
+extern void _event_loop_idle_cb(uintptr_t handle);
+
+static int _event_loop_fd_func(int fd, uint32_t mask, void *data) {
+	return _event_loop_fd_cb(fd, mask, (uintptr_t)data);
+}
+
+static int _event_loop_timer_func(void *data) {
+	return _event_loop_timer_cb((uintptr_t)data);
+}
+
+static int _event_loop_signal_func(int signal_number, void *data) {
+	return _event_loop_signal_cb(signal_number, (uintptr_t)data);
+}
+
+static void _event_loop_idle_func(void *data) {
+	_event_loop_idle_cb((uintptr_t)data);
+}
+
+static inline struct wl_event_source *_wl_event_loop_add_fd(struct wl_event_loop *loop, int fd, uint32_t mask, uintptr_t handle) {
+	return wl_event_loop_add_fd(loop, fd, mask, _event_loop_fd_func, (void *)handle);
+}
+
+static inline struct wl_event_source *_wl_event_loop_add_timer(struct wl_event_loop *loop, uintptr_t handle) {
+	return wl_event_loop_add_timer(loop, _event_loop_timer_func, (void *)handle);
+}
+
+static inline struct wl_event_source *_wl_event_loop_add_signal(struct wl_event_loop *loop, int signal_number, uintptr_t handle) {
+	return wl_event_loop_add_signal(loop, signal_number, _event_loop_signal_func, (void *)handle);
+}
+
+static inline struct wl_event_source *_wl_event_loop_add_idle(struct wl_event_loop *loop, uintptr_t handle) {
+	return wl_event_loop_add_idle(loop, _event_loop_idle_func, (void *)handle);
+}
+*/
+import "C"
+
+import (
+	"errors"
+	"runtime/cgo"
+	"syscall"
+	"time"
+	"unsafe"
+)
+
+type EventMask uint32
+
+const (
+	EventReadable EventMask = C.WL_EVENT_READABLE
+	EventWritable EventMask = C.WL_EVENT_WRITABLE
+	EventHangup   EventMask = C.WL_EVENT_HANGUP
+	EventError    EventMask = C.WL_EVENT_ERROR
+)
+
+type EventLoop struct {
+	p *C.struct_wl_event_loop
+}
+
+func (evl EventLoop) OnDestroy(cb func(EventLoop)) Listener {
+	lis := newListener(nil, func(lis Listener, data unsafe.Pointer) {
+		cb(evl)
+	})
+	C.wl_event_loop_add_destroy_listener(evl.p, &lis.p.lis)
+	return lis
+}
+
+func (evl EventLoop) Fd() uintptr {
+	return uintptr(C.wl_event_loop_get_fd(evl.p))
+}
+
+func (evl EventLoop) Dispatch(timeout time.Duration) {
+	var d int
+	if timeout >= 0 {
+		d = int(timeout / time.Millisecond)
+	} else {
+		d = -1
+	}
+
+	defer enterLoop(evl)()
+	C.wl_event_loop_dispatch(evl.p, C.int(d))
+}
+
+// DispatchIdle runs all pending idle callbacks.
+func (evl EventLoop) DispatchIdle() {
+	C.wl_event_loop_dispatch_idle(evl.p)
+}
+
+// AddFd calls cb whenever any of the events in mask occur on fd. The
+// loop does not take ownership of fd.
+func (evl EventLoop) AddFd(fd uintptr, mask EventMask, cb func(fd uintptr, mask EventMask)) EventSource {
+	checkLoopThread(evl.p)
+
+	handle := cgo.NewHandle(cb)
+	p := C._wl_event_loop_add_fd(evl.p, C.int(fd), C.uint32_t(mask), C.uintptr_t(handle))
+	return newEventSource(evl, p, handle)
+}
+
+// AddTimer creates a timer that calls cb when it expires. The timer
+// is initially disarmed. Use UpdateTimer to arm it.
+func (evl EventLoop) AddTimer(cb func()) EventSource {
+	checkLoopThread(evl.p)
+
+	handle := cgo.NewHandle(cb)
+	p := C._wl_event_loop_add_timer(evl.p, C.uintptr_t(handle))
+	return newEventSource(evl, p, handle)
+}
+
+// AddSignal calls cb on the loop whenever sig is received. sig is
+// blocked for the calling thread, so this interacts poorly with the
+// Go runtime's own signal handling and os/signal should usually be
+// preferred.
+func (evl EventLoop) AddSignal(sig syscall.Signal, cb func(syscall.Signal)) EventSource {
+	checkLoopThread(evl.p)
+
+	handle := cgo.NewHandle(cb)
+	p := C._wl_event_loop_add_signal(evl.p, C.int(sig), C.uintptr_t(handle))
+	return newEventSource(evl, p, handle)
+}
+
+// AddIdle calls cb once the next time that the loop has nothing else
+// to do. The source is removed automatically after cb has been
+// called, after which Remove must not be called on it.
+func (evl EventLoop) AddIdle(cb func()) EventSource {
+	checkLoopThread(evl.p)
+
+	handle := cgo.NewHandle(cb)
+	p := C._wl_event_loop_add_idle(evl.p, C.uintptr_t(handle))
+	return newEventSource(evl, p, handle)
+}
+
+// EventSource is a source of events attached to an EventLoop.
+type EventSource struct {
+	p      *C.struct_wl_event_source
+	loop   *C.struct_wl_event_loop
+	handle cgo.Handle
+}
+
+func newEventSource(evl EventLoop, p *C.struct_wl_event_source, handle cgo.Handle) EventSource {
+	if p == nil {
+		handle.Delete()
+		return EventSource{}
+	}
+
+	return EventSource{p: p, loop: evl.p, handle: handle}
+}
+
+func (s EventSource) Valid() bool {
+	return s.p != nil
+}
+
+// Update changes the events that a source created by AddFd is
+// waiting for.
+func (s EventSource) Update(mask EventMask) error {
+	checkLoopThread(s.loop)
+
+	if C.wl_event_source_fd_update(s.p, C.uint32_t(mask)) != 0 {
+		return errors.New("can't update event source")
+	}
+
+	return nil
+}
+
+// UpdateTimer arms a source created by AddTimer to expire after
+// delay, which has millisecond precision. A delay of zero disarms the
+// timer.
+func (s EventSource) UpdateTimer(delay time.Duration) error {
+	checkLoopThread(s.loop)
+
+	ms := delay.Milliseconds()
+	if delay > 0 {
+		ms = max(ms, 1)
+	}
+
+	if C.wl_event_source_timer_update(s.p, C.int(ms)) != 0 {
+		return errors.New("can't update timer")
+	}
+
+	return nil
+}
+
+// Check marks the source to be checked again after the next dispatch
+// of the loop, even if no new events arrive for it.
+func (s EventSource) Check() {
+	checkLoopThread(s.loop)
+
+	C.wl_event_source_check(s.p)
+}
+
+// Remove removes the source from its loop and frees its resources.
+func (s EventSource) Remove() {
+	checkLoopThread(s.loop)
+
+	C.wl_event_source_remove(s.p)
+	s.handle.Delete()
+}
+
+//export _event_loop_fd_cb
+func _event_loop_fd_cb(fd C.int, mask C.uint32_t, handle C.uintptr_t) C.int {
+	cb := cgo.Handle(handle).Value().(func(uintptr, EventMask))
+	cb(uintptr(fd), EventMask(mask))
+	return 0
+}
+
+//export _event_loop_timer_cb
+func _event_loop_timer_cb(handle C.uintptr_t) C.int {
+	cb := cgo.Handle(handle).Value().(func())
+	cb()
+	return 0
+}
+
+//export _event_loop_signal_cb
+func _event_loop_signal_cb(sig C.int, handle C.uintptr_t) C.int {
+	cb := cgo.Handle(handle).Value().(func(syscall.Signal))
+	cb(syscall.Signal(sig))
+	return 0
+}
+
+//export _event_loop_idle_cb
+func _event_loop_idle_cb(handle C.uintptr_t) {
+	h := cgo.Handle(handle)
+	defer h.Delete()
+
+	h.Value().(func())()
+}
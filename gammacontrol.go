@@ -12,6 +12,8 @@ type GammaControlManagerV1 struct {
 }
 
 func CreateGammaControlManagerV1(display Display) GammaControlManagerV1 {
+	checkThread()
+
 	p := C.wlr_gamma_control_manager_v1_create(display.p)
 	return GammaControlManagerV1{p: p}
 }
@@ -0,0 +1,166 @@
+package wlr
+
+/*
+#include <stdlib.h>
+#include <wayland-server-core.h>
+
+extern void _global_bind_cb(struct wl_client *client, void *data, uint32_t version, uint32_t id);
+
+static inline struct wl_global *_wl_global_create(struct wl_display *display, const struct wl_interface *iface, int version, uintptr_t handle) {
+	return wl_global_create(display, iface, version, (void *)handle, _global_bind_cb);
+}
+
+static inline uintptr_t _wl_global_get_handle(struct wl_global *global) {
+	return (uintptr_t)wl_global_get_user_data(global);
+}
+*/
+import "C"
+
+import (
+	"runtime/cgo"
+	"sync"
+	"unsafe"
+)
+
+// Interface describes a Wayland protocol interface. It is the Go
+// equivalent of a wl_interface as generated by wayland-scanner.
+//
+// An Interface is converted to its C representation the first time
+// that it is used and must not be modified afterwards. The C
+// representation is never freed, so Interfaces should generally be
+// declared once as package-level variables.
+type Interface struct {
+	Name     string
+	Version  int
+	Requests []Message
+	Events   []Message
+}
+
+// Message describes a single request or event of an Interface.
+// Signature uses the same format as the wire protocol's message
+// signatures, such as "2?ou". Types has one entry per argument in
+// the signature, which is the Interface of object and new_id
+// arguments and nil for everything else.
+type Message struct {
+	Name      string
+	Signature string
+	Types     []*Interface
+}
+
+var (
+	namedMu sync.Mutex
+	named   = make(map[string]*Interface)
+)
+
+// InterfaceByName returns the shared Interface with the given name,
+// creating an empty one the first time that the name is used. Code
+// generated by protogen declares its interfaces through it and uses
+// it to refer to interfaces from other protocols, so every package
+// sees the same Interface for a given name and several protocols can
+// be generated into one package without conflicting declarations.
+func InterfaceByName(name string) *Interface {
+	namedMu.Lock()
+	defer namedMu.Unlock()
+
+	iface, ok := named[name]
+	if !ok {
+		iface = &Interface{Name: name}
+		named[name] = iface
+	}
+	return iface
+}
+
+var interfaces = make(map[*Interface]*C.struct_wl_interface)
+
+func (iface *Interface) toC() *C.struct_wl_interface {
+	if iface == nil {
+		return nil
+	}
+	if ci, ok := interfaces[iface]; ok {
+		return ci
+	}
+
+	ci := (*C.struct_wl_interface)(C.calloc(1, C.sizeof_struct_wl_interface))
+	// Register before converting messages so that self-referential and
+	// cyclic types resolve to the same pointer.
+	interfaces[iface] = ci
+
+	ci.name = C.CString(iface.Name)
+	ci.version = C.int(iface.Version)
+	ci.method_count = C.int(len(iface.Requests))
+	ci.methods = messagesToC(iface.Requests)
+	ci.event_count = C.int(len(iface.Events))
+	ci.events = messagesToC(iface.Events)
+	return ci
+}
+
+func messagesToC(msgs []Message) *C.struct_wl_message {
+	if len(msgs) == 0 {
+		return nil
+	}
+
+	p := (*C.struct_wl_message)(C.calloc(C.size_t(len(msgs)), C.sizeof_struct_wl_message))
+	cmsgs := unsafe.Slice(p, len(msgs))
+	for i, msg := range msgs {
+		cmsgs[i].name = C.CString(msg.Name)
+		cmsgs[i].signature = C.CString(msg.Signature)
+		if len(msg.Types) == 0 {
+			continue
+		}
+
+		types := (**C.struct_wl_interface)(C.calloc(C.size_t(len(msg.Types)), C.size_t(unsafe.Sizeof((*C.struct_wl_interface)(nil)))))
+		ctypes := unsafe.Slice(types, len(msg.Types))
+		for j, t := range msg.Types {
+			ctypes[j] = t.toC()
+		}
+		cmsgs[i].types = types
+	}
+	return p
+}
+
+// BindFunc is called when a client binds to a Global. It should
+// create a resource using CreateResource with the given version and
+// id.
+type BindFunc func(client Client, version, id uint32)
+
+// Global is a Wayland global that is advertised to clients, allowing
+// them to bind to it.
+type Global struct {
+	p *C.struct_wl_global
+}
+
+// CreateGlobal creates and advertises a global implementing iface.
+// version is the highest version of the interface that the global
+// supports.
+func CreateGlobal(display Display, iface *Interface, version uint32, bind BindFunc) Global {
+	checkThread()
+
+	handle := cgo.NewHandle(bind)
+	p := C._wl_global_create(display.p, iface.toC(), C.int(version), C.uintptr_t(handle))
+	if p == nil {
+		handle.Delete()
+	}
+	return Global{p: p}
+}
+
+func (g Global) Valid() bool {
+	return g.p != nil
+}
+
+// Remove stops advertising the global to clients without destroying
+// it, giving clients time to stop using it before it is destroyed.
+func (g Global) Remove() {
+	C.wl_global_remove(g.p)
+}
+
+func (g Global) Destroy() {
+	handle := cgo.Handle(C._wl_global_get_handle(g.p))
+	C.wl_global_destroy(g.p)
+	handle.Delete()
+}
+
+//export _global_bind_cb
+func _global_bind_cb(client *C.struct_wl_client, data unsafe.Pointer, version C.uint32_t, id C.uint32_t) {
+	bind := cgo.Handle(uintptr(data)).Value().(BindFunc)
+	bind(Client{p: client}, uint32(version), uint32(id))
+}
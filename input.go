@@ -4,6 +4,9 @@ package wlr
 #include <wlr/types/wlr_input_device.h>
 #include <wlr/types/wlr_keyboard.h>
 #include <wlr/types/wlr_pointer.h>
+#include <wlr/types/wlr_tablet_pad.h>
+#include <wlr/types/wlr_tablet_tool.h>
+#include <wlr/types/wlr_touch.h>
 */
 import "C"
 
@@ -152,6 +155,21 @@ func (d InputDevice) Pointer() Pointer {
 	return Pointer{p: p}
 }
 
+func (d InputDevice) Touch() Touch {
+	p := C.wlr_touch_from_input_device(d.p)
+	return Touch{p: p}
+}
+
+func (d InputDevice) Tablet() Tablet {
+	p := C.wlr_tablet_from_input_device(d.p)
+	return Tablet{p: p}
+}
+
+func (d InputDevice) TabletPad() TabletPad {
+	p := C.wlr_tablet_pad_from_input_device(d.p)
+	return TabletPad{p: p}
+}
+
 type KeyboardModifiers struct {
 	p *C.struct_wlr_keyboard_modifiers
 }
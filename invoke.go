@@ -0,0 +1,206 @@
+package wlr
+
+/*
+#include <sys/eventfd.h>
+#include <unistd.h>
+*/
+import "C"
+
+import (
+	"fmt"
+	"runtime"
+	"sync"
+	"sync/atomic"
+	"syscall"
+)
+
+// invokeQueue holds functions that have been queued from other
+// goroutines to be run on an event loop's thread. The loop is woken
+// up to run them via an eventfd. It also records which thread, if
+// any, is currently running the loop.
+type invokeQueue struct {
+	fd     C.int
+	src    EventSource
+	thread atomic.Int64
+
+	m     sync.Mutex
+	funcs []func()
+}
+
+var queues sync.Map // map[*C.struct_wl_event_loop]*invokeQueue
+
+// attachInvokeQueue sets up an invokeQueue for evl. It must be called
+// before evl is used by more than one goroutine.
+func attachInvokeQueue(evl EventLoop) {
+	fd := C.eventfd(0, C.EFD_CLOEXEC|C.EFD_NONBLOCK)
+	if fd < 0 {
+		panic("can't create eventfd for event loop")
+	}
+
+	q := &invokeQueue{fd: fd}
+	q.src = evl.AddFd(uintptr(fd), EventReadable, func(uintptr, EventMask) { q.run() })
+	queues.Store(evl.p, q)
+
+	var lis Listener
+	lis = evl.OnDestroy(func(evl EventLoop) {
+		lis.Destroy()
+		queues.Delete(evl.p)
+		q.src.Remove()
+		C.close(q.fd)
+	})
+}
+
+// loopQueue returns the invokeQueue of evl, or nil if evl was not
+// created by CreateDisplay.
+func loopQueue(evl *C.struct_wl_event_loop) *invokeQueue {
+	q, ok := queues.Load(evl)
+	if !ok {
+		return nil
+	}
+	return q.(*invokeQueue)
+}
+
+func (q *invokeQueue) push(f func()) {
+	q.m.Lock()
+	q.funcs = append(q.funcs, f)
+	q.m.Unlock()
+
+	C.eventfd_write(q.fd, 1)
+}
+
+func (q *invokeQueue) run() {
+	var v C.eventfd_t
+	C.eventfd_read(q.fd, &v)
+
+	q.m.Lock()
+	funcs := q.funcs
+	q.funcs = nil
+	q.m.Unlock()
+
+	for _, f := range funcs {
+		f()
+	}
+}
+
+// Go queues f to be run on the thread that is running the event loop.
+// Unlike every other method in this package, it is safe to call from
+// any goroutine. It returns immediately without waiting for f to run.
+//
+// Functions are run in the order that they were queued.
+func (evl EventLoop) Go(f func()) {
+	q := loopQueue(evl.p)
+	if q == nil {
+		panic("event loop was not created by CreateDisplay")
+	}
+	q.push(f)
+}
+
+// Invoke runs f on the thread that is running the display's event
+// loop and waits for it to return. If it is called from the loop's
+// thread, such as from inside of an event handler, f is run
+// immediately.
+//
+// Invoke is safe to call from any goroutine, but calling it from
+// another goroutine while the loop is not running will block until
+// it is.
+func (d Display) Invoke(f func()) {
+	if q := loopQueue(d.EventLoop().p); q != nil && q.onThread() {
+		f()
+		return
+	}
+
+	done := make(chan struct{})
+	d.EventLoop().Go(func() {
+		defer close(done)
+		f()
+	})
+	<-done
+}
+
+var threadChecks atomic.Bool
+
+// enterLoop records the calling thread as the thread running evl. The
+// returned function must be called when the loop stops.
+func enterLoop(evl EventLoop) func() {
+	runtime.LockOSThread()
+
+	q := loopQueue(evl.p)
+	if q == nil {
+		return runtime.UnlockOSThread
+	}
+
+	prev := q.thread.Swap(int64(syscall.Gettid()))
+	return func() {
+		q.thread.Store(prev)
+		runtime.UnlockOSThread()
+	}
+}
+
+// onThread returns true if the calling thread is running the queue's
+// loop.
+func (q *invokeQueue) onThread() bool {
+	return q.thread.Load() == int64(syscall.Gettid())
+}
+
+// SetThreadChecks enables or disables a debugging mode that panics
+// if the package is used from the wrong thread while an event loop is
+// running. Use Display.Invoke or EventLoop.Go to run code on the
+// correct thread.
+//
+// Methods of EventLoop and EventSource are checked against the thread
+// running their own loop. Adding or removing listeners, constructors,
+// and functions that send or notify clients of events, change a
+// seat's focus, grabs, drags or selection, commit state, or post
+// events and errors are checked against the threads running any
+// loop, as the loop that an object belongs to is not generally known.
+// Other methods, such as simple getters, are not checked.
+//
+// Checks are disabled by default.
+func SetThreadChecks(enabled bool) {
+	threadChecks.Store(enabled)
+}
+
+// checkThread panics if thread checks are enabled, any event loop is
+// running, and the calling thread is not running one of them.
+func checkThread() {
+	if !threadChecks.Load() {
+		return
+	}
+
+	cur := int64(syscall.Gettid())
+	running, ok := false, false
+	queues.Range(func(_, q any) bool {
+		tid := q.(*invokeQueue).thread.Load()
+		if tid == 0 {
+			return true
+		}
+		running = true
+		ok = tid == cur
+		return !ok
+	})
+	if running && !ok {
+		panic(fmt.Errorf("wlr called from thread %v, which is not running an event loop", cur))
+	}
+}
+
+// checkLoopThread panics if thread checks are enabled, evl is running,
+// and the calling thread is not the one running it.
+func checkLoopThread(evl *C.struct_wl_event_loop) {
+	if !threadChecks.Load() {
+		return
+	}
+
+	q := loopQueue(evl)
+	if q == nil {
+		checkThread()
+		return
+	}
+
+	tid := q.thread.Load()
+	if tid == 0 {
+		return
+	}
+	if cur := syscall.Gettid(); int64(cur) != tid {
+		panic(fmt.Errorf("wlr called from thread %v while event loop is running on thread %v", cur, tid))
+	}
+}
@@ -0,0 +1,93 @@
+package wlr
+
+/*
+#include <wlr/types/wlr_keyboard_group.h>
+*/
+import "C"
+
+import "unsafe"
+
+// KeyboardGroup combines several keyboards into a single Keyboard so
+// that, for example, a modifier held on one keyboard applies to keys
+// pressed on another. All keyboards in a group must have the same
+// keymap and repeat info.
+type KeyboardGroup struct {
+	p *C.struct_wlr_keyboard_group
+}
+
+func CreateKeyboardGroup() KeyboardGroup {
+	checkThread()
+
+	p := C.wlr_keyboard_group_create()
+	return KeyboardGroup{p: p}
+}
+
+// KeyboardGroupFromKeyboard returns the group whose merged keyboard is
+// k. It is invalid if k is not the keyboard of a group. To find the
+// group that k has been added to, use Keyboard.Group.
+func KeyboardGroupFromKeyboard(k Keyboard) KeyboardGroup {
+	p := C.wlr_keyboard_group_from_wlr_keyboard(k.p)
+	return KeyboardGroup{p: p}
+}
+
+func (g KeyboardGroup) Valid() bool {
+	return g.p != nil
+}
+
+// Destroy removes all of the keyboards from the group and destroys
+// it.
+func (g KeyboardGroup) Destroy() {
+	C.wlr_keyboard_group_destroy(g.p)
+}
+
+// Keyboard returns the merged keyboard of the group. It emits the key
+// and modifier events of all of the keyboards in the group and is
+// what should be passed to Seat.SetKeyboard. Its keymap should be set
+// before any keyboards are added.
+func (g KeyboardGroup) Keyboard() Keyboard {
+	return Keyboard{p: &g.p.keyboard}
+}
+
+// AddKeyboard adds k to the group. It returns false if k is already in
+// a group or if its keymap or repeat info do not match the group's.
+func (g KeyboardGroup) AddKeyboard(k Keyboard) bool {
+	return bool(C.wlr_keyboard_group_add_keyboard(g.p, k.p))
+}
+
+func (g KeyboardGroup) RemoveKeyboard(k Keyboard) {
+	C.wlr_keyboard_group_remove_keyboard(g.p, k.p)
+}
+
+// OnEnter is called with the keys that were already pressed on a
+// keyboard when it was added to the group. The group does not emit
+// key events for them.
+func (g KeyboardGroup) OnEnter(cb func(g KeyboardGroup, keycodes []uint32)) Listener {
+	return newListener(&g.p.events.enter, func(lis Listener, data unsafe.Pointer) {
+		cb(g, wlArrayUint32s((*C.struct_wl_array)(data)))
+	})
+}
+
+// OnLeave is called with the keys that were still pressed on a
+// keyboard when it was removed from the group. The group does not emit
+// key events for them.
+func (g KeyboardGroup) OnLeave(cb func(g KeyboardGroup, keycodes []uint32)) Listener {
+	return newListener(&g.p.events.leave, func(lis Listener, data unsafe.Pointer) {
+		cb(g, wlArrayUint32s((*C.struct_wl_array)(data)))
+	})
+}
+
+// Group returns the group that k has been added to. It is invalid if k
+// is not in a group.
+func (k Keyboard) Group() KeyboardGroup {
+	return KeyboardGroup{p: k.p.group}
+}
+
+// wlArrayUint32s copies a wl_array of uint32_t.
+func wlArrayUint32s(a *C.struct_wl_array) []uint32 {
+	n := uintptr(a.size) / unsafe.Sizeof(uint32(0))
+	if n == 0 {
+		return nil
+	}
+
+	return append([]uint32(nil), unsafe.Slice((*uint32)(a.data), n)...)
+}
@@ -2,16 +2,22 @@ package wlr
 
 /*
 #include <wlr/types/wlr_layer_shell_v1.h>
+#include <wlr/types/wlr_xdg_shell.h>
 */
 import "C"
 
-import "unsafe"
+import (
+	"image"
+	"unsafe"
+)
 
 type LayerShellV1 struct {
 	p *C.struct_wlr_layer_shell_v1
 }
 
 func CreateLayerShellV1(display Display, version uint32) LayerShellV1 {
+	checkThread()
+
 	p := C.wlr_layer_shell_v1_create(display.p, C.uint32_t(version))
 	return LayerShellV1{p: p}
 }
@@ -32,12 +38,167 @@ type LayerSurfaceV1 struct {
 	p *C.struct_wlr_layer_surface_v1
 }
 
+// LayerSurfaceV1FromSurface returns the layer surface role object of
+// s. It is invalid if s is not a layer surface.
+func LayerSurfaceV1FromSurface(s Surface) LayerSurfaceV1 {
+	p := C.wlr_layer_surface_v1_try_from_wlr_surface(s.p)
+	return LayerSurfaceV1{p: p}
+}
+
+func (s LayerSurfaceV1) Valid() bool {
+	return s.p != nil
+}
+
 func (s LayerSurfaceV1) Surface() Surface {
 	return Surface{
 		p: s.p.surface,
 	}
 }
 
+// Output returns the output that the surface should be displayed on.
+// If the client did not specify one, it is invalid and the compositor
+// is expected to pick one and call SetOutput.
+func (s LayerSurfaceV1) Output() Output {
+	return Output{p: s.p.output}
+}
+
+func (s LayerSurfaceV1) SetOutput(output Output) {
+	s.p.output = output.p
+}
+
+// Namespace returns the client-provided identifier of the surface's
+// purpose, such as "panel" or "wallpaper".
+func (s LayerSurfaceV1) Namespace() string {
+	return C.GoString(s.p.namespace)
+}
+
+// Initialized returns true once the client has made its initial
+// commit and the surface is ready to be configured.
+func (s LayerSurfaceV1) Initialized() bool {
+	return bool(s.p.initialized)
+}
+
+func (s LayerSurfaceV1) Configured() bool {
+	return bool(s.p.configured)
+}
+
+func (s LayerSurfaceV1) Current() LayerSurfaceV1State {
+	return LayerSurfaceV1State{v: s.p.current}
+}
+
+func (s LayerSurfaceV1) Pending() LayerSurfaceV1State {
+	return LayerSurfaceV1State{v: s.p.pending}
+}
+
+// Configure sends a configure event with the given size and returns
+// its serial. A width or height of zero lets the client pick that
+// dimension.
+func (s LayerSurfaceV1) Configure(width, height uint32) uint32 {
+	return uint32(C.wlr_layer_surface_v1_configure(s.p, C.uint32_t(width), C.uint32_t(height)))
+}
+
+// Destroy notifies the client that the surface has been closed, such
+// as because its output was removed, and destroys it.
+func (s LayerSurfaceV1) Destroy() {
+	C.wlr_layer_surface_v1_destroy(s.p)
+}
+
+func (s LayerSurfaceV1) OnDestroy(cb func(LayerSurfaceV1)) Listener {
+	return newListener(&s.p.events.destroy, func(lis Listener, data unsafe.Pointer) {
+		cb(s)
+	})
+}
+
+func (s LayerSurfaceV1) OnNewPopup(cb func(XDGPopup)) Listener {
+	return newListener(&s.p.events.new_popup, func(lis Listener, data unsafe.Pointer) {
+		cb(XDGPopup{p: (*C.struct_wlr_xdg_popup)(data)})
+	})
+}
+
+// OnMap is a convenience method that listens for the layer surface's
+// surface being mapped.
+func (s LayerSurfaceV1) OnMap(cb func(LayerSurfaceV1)) Listener {
+	return s.Surface().OnMap(func(Surface) { cb(s) })
+}
+
+// OnUnmap is a convenience method that listens for the layer surface's
+// surface being unmapped.
+func (s LayerSurfaceV1) OnUnmap(cb func(LayerSurfaceV1)) Listener {
+	return s.Surface().OnUnmap(func(Surface) { cb(s) })
+}
+
+// SurfaceAt finds the surface, either the layer surface itself, one
+// of its subsurfaces, or one of its popups, at the given
+// surface-local coordinates.
+func (s LayerSurfaceV1) SurfaceAt(sx, sy float64) (surface Surface, subX, subY float64, ok bool) {
+	var csubX, csubY C.double
+	p := C.wlr_layer_surface_v1_surface_at(s.p, C.double(sx), C.double(sy), &csubX, &csubY)
+	return Surface{p: p}, float64(csubX), float64(csubY), p != nil
+}
+
+type LayerSurfaceV1State struct {
+	v C.struct_wlr_layer_surface_v1_state
+}
+
+func (s LayerSurfaceV1State) Anchor() LayerSurfaceV1Anchor {
+	return LayerSurfaceV1Anchor(s.v.anchor)
+}
+
+// ExclusiveZone returns the distance from the anchored edge that the
+// surface wants other surfaces to avoid. A value of zero means that
+// the surface should be moved to avoid other exclusive zones, and a
+// negative value means that it should be ignored.
+func (s LayerSurfaceV1State) ExclusiveZone() int32 {
+	return int32(s.v.exclusive_zone)
+}
+
+func (s LayerSurfaceV1State) Margin() (top, right, bottom, left int32) {
+	m := s.v.margin
+	return int32(m.top), int32(m.right), int32(m.bottom), int32(m.left)
+}
+
+func (s LayerSurfaceV1State) KeyboardInteractive() LayerSurfaceV1KeyboardInteractivity {
+	return LayerSurfaceV1KeyboardInteractivity(s.v.keyboard_interactive)
+}
+
+// DesiredSize returns the size requested by the client. A dimension
+// of zero means that the surface should be stretched between its
+// anchors in that direction.
+func (s LayerSurfaceV1State) DesiredSize() (width, height uint32) {
+	return uint32(s.v.desired_width), uint32(s.v.desired_height)
+}
+
+// ActualSize returns the size most recently sent to the client via
+// Configure.
+func (s LayerSurfaceV1State) ActualSize() (width, height uint32) {
+	return uint32(s.v.actual_width), uint32(s.v.actual_height)
+}
+
+func (s LayerSurfaceV1State) Layer() LayerShellV1Layer {
+	return LayerShellV1Layer(s.v.layer)
+}
+
+func (s LayerSurfaceV1State) ConfigureSerial() uint32 {
+	return uint32(s.v.configure_serial)
+}
+
+type LayerSurfaceV1Anchor uint32
+
+const (
+	LayerSurfaceV1AnchorTop    LayerSurfaceV1Anchor = C.ZWLR_LAYER_SURFACE_V1_ANCHOR_TOP
+	LayerSurfaceV1AnchorBottom LayerSurfaceV1Anchor = C.ZWLR_LAYER_SURFACE_V1_ANCHOR_BOTTOM
+	LayerSurfaceV1AnchorLeft   LayerSurfaceV1Anchor = C.ZWLR_LAYER_SURFACE_V1_ANCHOR_LEFT
+	LayerSurfaceV1AnchorRight  LayerSurfaceV1Anchor = C.ZWLR_LAYER_SURFACE_V1_ANCHOR_RIGHT
+)
+
+type LayerSurfaceV1KeyboardInteractivity uint32
+
+const (
+	LayerSurfaceV1KeyboardInteractivityNone      LayerSurfaceV1KeyboardInteractivity = C.ZWLR_LAYER_SURFACE_V1_KEYBOARD_INTERACTIVITY_NONE
+	LayerSurfaceV1KeyboardInteractivityExclusive LayerSurfaceV1KeyboardInteractivity = C.ZWLR_LAYER_SURFACE_V1_KEYBOARD_INTERACTIVITY_EXCLUSIVE
+	LayerSurfaceV1KeyboardInteractivityOnDemand  LayerSurfaceV1KeyboardInteractivity = C.ZWLR_LAYER_SURFACE_V1_KEYBOARD_INTERACTIVITY_ON_DEMAND
+)
+
 type LayerShellV1Layer int
 
 const (
@@ -46,3 +207,43 @@ const (
 	LayerShellV1LayerTop        LayerShellV1Layer = C.ZWLR_LAYER_SHELL_V1_LAYER_TOP
 	LayerShellV1LayerOverlay    LayerShellV1Layer = C.ZWLR_LAYER_SHELL_V1_LAYER_OVERLAY
 )
+
+// ArrangeSceneLayerSurfacesV1 configures and positions surfaces, which
+// should all be on the same output, within fullArea, usually the
+// output's box in the output layout. It returns what remains of
+// fullArea after the exclusive zones of the surfaces have been
+// removed, which is the area available for ordinary windows.
+//
+// Surfaces with an exclusive zone are arranged first, from the
+// overlay layer down to the background layer, and then the remaining
+// surfaces are arranged in the same order, so that surfaces without
+// an exclusive zone avoid those with one regardless of layer.
+// Surfaces that have not yet been initialized are skipped.
+func ArrangeSceneLayerSurfacesV1(fullArea image.Rectangle, surfaces []SceneLayerSurfaceV1) image.Rectangle {
+	usable := fullArea
+	layers := []LayerShellV1Layer{
+		LayerShellV1LayerOverlay,
+		LayerShellV1LayerTop,
+		LayerShellV1LayerBottom,
+		LayerShellV1LayerBackground,
+	}
+	for _, exclusive := range []bool{true, false} {
+		for _, layer := range layers {
+			for _, s := range surfaces {
+				ls := s.LayerSurface()
+				if !ls.Initialized() {
+					continue
+				}
+
+				state := ls.Current()
+				if state.Layer() != layer || (state.ExclusiveZone() > 0) != exclusive {
+					continue
+				}
+
+				usable = s.Configure(fullArea, usable)
+			}
+		}
+	}
+
+	return usable
+}
@@ -23,7 +23,14 @@ static inline void _listener_set_callback(struct wl_listener *listener) {
 import "C"
 
 import (
+	"cmp"
+	"fmt"
+	"runtime"
 	"runtime/cgo"
+	"slices"
+	"strings"
+	"sync"
+	"sync/atomic"
 	"unsafe"
 )
 
@@ -40,6 +47,8 @@ type Listener struct {
 }
 
 func newListener(sig *C.struct_wl_signal, cb listenerFunc) Listener {
+	checkThread()
+
 	lis := Listener{
 		p: (*C.struct__listener_data)(C.malloc(C.sizeof_struct__listener_data)),
 	}
@@ -50,6 +59,10 @@ func newListener(sig *C.struct_wl_signal, cb listenerFunc) Listener {
 		C.wl_signal_add(sig, &lis.p.lis)
 	}
 
+	if listenerTracking.Load() {
+		trackListener(lis)
+	}
+
 	return lis
 }
 
@@ -62,7 +75,9 @@ func (lis Listener) Destroy() {
 	if !lis.Valid() {
 		return
 	}
+	checkThread()
 
+	untrackListener(lis)
 	cgo.Handle(lis.p.handle).Delete()
 	C.wl_list_remove(&lis.p.lis.link)
 	C.free(unsafe.Pointer(lis.p))
@@ -84,3 +99,132 @@ func _listener_callback(p *C.struct_wl_listener, data unsafe.Pointer) {
 	lis := Listener{p: C._listener_get_data(p)}
 	lis.call(data)
 }
+
+var (
+	listenerTracking atomic.Bool
+	liveListenersM   sync.Mutex
+	liveListeners    = make(map[*C.struct__listener_data]ListenerInfo)
+	listenerSeq      uint64
+)
+
+// SetListenerTracking enables or disables tracking of live
+// Listeners. While it is enabled, every new Listener records the
+// call stack that created it until it is destroyed. Tracked Listeners
+// can be retrieved with LiveListeners, which makes it possible to
+// find Listeners that were never destroyed, such as by checking that
+// none remain after a compositor has been torn down in a test.
+//
+// Tracking has a noticeable cost and is disabled by default.
+// Listeners created while it is disabled are never tracked.
+func SetListenerTracking(enabled bool) {
+	listenerTracking.Store(enabled)
+}
+
+// ListenerInfo describes a live Listener.
+type ListenerInfo struct {
+	seq uint64
+	pcs []uintptr
+}
+
+// Stack returns the call stack at the point where the Listener was
+// created, starting with the caller of the function that created it.
+func (info ListenerInfo) Stack() []runtime.Frame {
+	frames := runtime.CallersFrames(info.pcs)
+	stack := make([]runtime.Frame, 0, len(info.pcs))
+	for {
+		frame, more := frames.Next()
+		stack = append(stack, frame)
+		if !more {
+			return stack
+		}
+	}
+}
+
+func (info ListenerInfo) String() string {
+	var buf strings.Builder
+	buf.WriteString("listener created at:")
+	for _, frame := range info.Stack() {
+		fmt.Fprintf(&buf, "\n\t%v\n\t\t%v:%v", frame.Function, frame.File, frame.Line)
+	}
+	return buf.String()
+}
+
+// LiveListeners returns information about every tracked Listener
+// that has not yet been destroyed, in the order that they were
+// created. See SetListenerTracking.
+func LiveListeners() []ListenerInfo {
+	liveListenersM.Lock()
+	defer liveListenersM.Unlock()
+
+	infos := make([]ListenerInfo, 0, len(liveListeners))
+	for _, info := range liveListeners {
+		infos = append(infos, info)
+	}
+	slices.SortFunc(infos, func(i1, i2 ListenerInfo) int { return cmp.Compare(i1.seq, i2.seq) })
+	return infos
+}
+
+func trackListener(lis Listener) {
+	pcs := make([]uintptr, 32)
+	// Skip runtime.Callers, trackListener, newListener, and the
+	// wrapper method that called newListener.
+	pcs = pcs[:runtime.Callers(4, pcs)]
+
+	liveListenersM.Lock()
+	defer liveListenersM.Unlock()
+
+	listenerSeq++
+	liveListeners[lis.p] = ListenerInfo{seq: listenerSeq, pcs: pcs}
+}
+
+func untrackListener(lis Listener) {
+	liveListenersM.Lock()
+	defer liveListenersM.Unlock()
+
+	delete(liveListeners, lis.p)
+}
+
+// ListenerGroup collects Listeners so that they can all be destroyed
+// at once, such as when the object that they are listening to is
+// destroyed. The zero value is an empty group ready to use.
+//
+// Listeners in a group should not also be destroyed individually.
+type ListenerGroup struct {
+	listeners []Listener
+}
+
+// Add adds listeners to the group.
+func (g *ListenerGroup) Add(listeners ...Listener) {
+	g.listeners = append(g.listeners, listeners...)
+}
+
+// Len returns the number of listeners in the group.
+func (g *ListenerGroup) Len() int {
+	return len(g.listeners)
+}
+
+// Destroy destroys every listener in the group and empties it. The
+// group may be reused afterwards.
+func (g *ListenerGroup) Destroy() {
+	listeners := g.listeners
+	g.listeners = nil
+	for _, lis := range listeners {
+		lis.Destroy()
+	}
+}
+
+// DestroyWith binds the lifetime of g to an object by destroying g
+// when onDestroy's event fires. onDestroy is usually an OnDestroy
+// method value, for example:
+//
+//	var g wlr.ListenerGroup
+//	g.Add(surface.OnMap(handleMap), surface.OnUnmap(handleUnmap))
+//	wlr.DestroyWith(&g, surface.OnDestroy)
+//
+// The listener registered with onDestroy is itself added to g. Any
+// other destroy handlers for the object that are registered before
+// the call to DestroyWith will still be called before the group is
+// destroyed.
+func DestroyWith[T any](g *ListenerGroup, onDestroy func(func(T)) Listener) {
+	g.Add(onDestroy(func(T) { g.Destroy() }))
+}
@@ -10,7 +10,6 @@ import "C"
 
 import (
 	"errors"
-	"image"
 	"iter"
 	"unsafe"
 )
@@ -19,6 +18,16 @@ type Output struct {
 	p *C.struct_wlr_output
 }
 
+// OutputFromResource returns the output of a wl_output resource.
+func OutputFromResource(r Resource) Output {
+	p := C.wlr_output_from_resource(r.p)
+	return Output{p: p}
+}
+
+func (o Output) Valid() bool {
+	return o.p != nil
+}
+
 func (o Output) OnDestroy(cb func(Output)) Listener {
 	return newListener(&o.p.events.destroy, func(lis Listener, data unsafe.Pointer) {
 		cb(o)
@@ -33,6 +42,7 @@ func (o Output) Scale() float32 {
 	return float32(o.p.scale)
 }
 
+// Deprecated: Use OutputState.SetScale with CommitState instead.
 func (o Output) SetScale(scale float32) {
 	C.wlr_output_set_scale(o.p, C.float(scale))
 }
@@ -41,6 +51,7 @@ func (o Output) Transform() OutputTransform {
 	return OutputTransform(o.p.transform)
 }
 
+// Deprecated: Use OutputState.SetTransform with CommitState instead.
 func (o Output) SetTransform(transform OutputTransform) {
 	C.wlr_output_set_transform(o.p, C.enum_wl_output_transform(transform))
 }
@@ -55,13 +66,35 @@ func (o Output) OnFrame(cb func(Output)) Listener {
 	})
 }
 
-func (o Output) RenderSoftwareCursors(damage image.Rectangle) {
-	var cd *C.pixman_region32_t
-	if damage != (image.Rectangle{}) {
-		t := rectToC(damage)
-		cd = &t
+// OnDamage is called when part of the output needs to be redrawn for
+// reasons that a compositor can't track by itself, such as a software
+// cursor moving. The damage is in output-buffer-local coordinates and
+// is only valid during the callback.
+func (o Output) OnDamage(cb func(Output, Region)) Listener {
+	return newListener(&o.p.events.damage, func(lis Listener, data unsafe.Pointer) {
+		event := (*C.struct_wlr_output_event_damage)(data)
+		cb(o, Region{p: event.damage})
+	})
+}
+
+// SetDamage sets the area of the output that was redrawn for the
+// pending frame, in output-buffer-local coordinates. This allows the
+// backend to avoid copying the rest of the buffer. If damage is not
+// valid, the damage is left unspecified and the entire buffer is
+// assumed to have been redrawn.
+func (o Output) SetDamage(damage Region) {
+	if !damage.Valid() {
+		return
 	}
-	C.wlr_output_render_software_cursors(o.p, cd)
+
+	C.wlr_output_set_damage(o.p, damage.p)
+}
+
+// RenderSoftwareCursors renders software cursors that intersect
+// damage, in output-buffer-local coordinates. If damage is not valid,
+// all software cursors are rendered.
+func (o Output) RenderSoftwareCursors(damage Region) {
+	C.wlr_output_render_software_cursors(o.p, damage.p)
 }
 
 func (o Output) TransformedResolution() (int, int) {
@@ -94,6 +127,8 @@ func (o Output) Rollback() {
 }
 
 func (o Output) CreateGlobal() {
+	checkThread()
+
 	C.wlr_output_create_global(o.p)
 }
 
@@ -101,8 +136,50 @@ func (o Output) DestroyGlobal() {
 	C.wlr_output_destroy_global(o.p)
 }
 
-func (o Output) Commit() {
-	C.wlr_output_commit(o.p)
+func (o Output) Commit() error {
+	checkThread()
+
+	if !C.wlr_output_commit(o.p) {
+		return errors.New("can't commit output")
+	}
+
+	return nil
+}
+
+// Test checks whether state could be applied to the output without
+// actually applying it.
+func (o Output) Test(state *OutputState) bool {
+	cs := state.toC()
+	defer C.wlr_output_state_finish(&cs)
+
+	return bool(C.wlr_output_test_state(o.p, &cs))
+}
+
+// CommitState atomically applies state to the output. If it can't be
+// applied, none of it is.
+func (o Output) CommitState(state *OutputState) error {
+	checkThread()
+
+	cs := state.toC()
+	defer C.wlr_output_state_finish(&cs)
+
+	if !C.wlr_output_commit_state(o.p, &cs) {
+		return errors.New("can't commit output state")
+	}
+
+	return nil
+}
+
+func (o Output) Enabled() bool {
+	return bool(o.p.enabled)
+}
+
+func (o Output) AdaptiveSyncStatus() bool {
+	return o.p.adaptive_sync_status == C.WLR_OUTPUT_ADAPTIVE_SYNC_ENABLED
+}
+
+func (o Output) CurrentMode() OutputMode {
+	return OutputMode{p: o.p.current_mode}
 }
 
 func (o Output) Modes() iter.Seq[OutputMode] {
@@ -117,10 +194,12 @@ func (o Output) Modes() iter.Seq[OutputMode] {
 	}
 }
 
+// Deprecated: Use OutputState.SetMode with CommitState instead.
 func (o Output) SetMode(mode OutputMode) {
 	C.wlr_output_set_mode(o.p, mode.p)
 }
 
+// Deprecated: Use OutputState.SetEnabled with CommitState instead.
 func (o Output) Enable(enable bool) {
 	C.wlr_output_enable(o.p, C.bool(enable))
 }
@@ -155,6 +234,8 @@ type OutputLayout struct {
 }
 
 func CreateOutputLayout() OutputLayout {
+	checkThread()
+
 	p := C.wlr_output_layout_create()
 	return OutputLayout{p: p}
 }
@@ -219,6 +300,157 @@ func (m OutputMode) Valid() bool {
 	return m.p != nil
 }
 
+type outputStateField uint32
+
+const (
+	outputStateEnabled outputStateField = 1 << iota
+	outputStateMode
+	outputStateCustomMode
+	outputStateScale
+	outputStateTransform
+	outputStateAdaptiveSync
+	outputStateRenderFormat
+	outputStateDamage
+)
+
+// OutputState is a set of changes to an output's configuration that
+// can be tested and committed atomically. Only fields which have been
+// explicitly set are applied. The zero value has no changes set.
+type OutputState struct {
+	fields outputStateField
+
+	enabled      bool
+	mode         OutputMode
+	customWidth  int32
+	customHeight int32
+	customRate   int32
+	scale        float32
+	transform    OutputTransform
+	adaptiveSync bool
+	renderFormat uint32
+	damage       Region
+}
+
+func (s *OutputState) SetEnabled(enabled bool) {
+	s.fields |= outputStateEnabled
+	s.enabled = enabled
+}
+
+// SetMode sets the output's mode to one of its advertised modes. It
+// overrides any previous call to SetCustomMode.
+func (s *OutputState) SetMode(mode OutputMode) {
+	s.fields = (s.fields &^ outputStateCustomMode) | outputStateMode
+	s.mode = mode
+}
+
+// SetCustomMode sets the output's mode to an arbitrary resolution and
+// refresh rate, in mHz. A refresh rate of zero lets the backend pick
+// one. It overrides any previous call to SetMode.
+func (s *OutputState) SetCustomMode(width, height, refresh int32) {
+	s.fields = (s.fields &^ outputStateMode) | outputStateCustomMode
+	s.customWidth = width
+	s.customHeight = height
+	s.customRate = refresh
+}
+
+func (s *OutputState) SetScale(scale float32) {
+	s.fields |= outputStateScale
+	s.scale = scale
+}
+
+func (s *OutputState) SetTransform(transform OutputTransform) {
+	s.fields |= outputStateTransform
+	s.transform = transform
+}
+
+func (s *OutputState) SetAdaptiveSyncEnabled(enabled bool) {
+	s.fields |= outputStateAdaptiveSync
+	s.adaptiveSync = enabled
+}
+
+// SetRenderFormat sets the DRM fourcc format of the output's
+// primary buffer.
+func (s *OutputState) SetRenderFormat(format uint32) {
+	s.fields |= outputStateRenderFormat
+	s.renderFormat = format
+}
+
+// SetDamage sets the area of the output that was redrawn, in
+// output-buffer-local coordinates. damage is copied when the state is
+// tested or committed, so it must remain valid until then. If damage
+// is not valid, the damage is left unspecified and the entire buffer
+// is assumed to have been redrawn.
+func (s *OutputState) SetDamage(damage Region) {
+	s.fields |= outputStateDamage
+	s.damage = damage
+}
+
+// Enabled returns the enabled state that will be applied and whether
+// or not it was set at all.
+func (s *OutputState) Enabled() (enabled, ok bool) {
+	return s.enabled, s.fields&outputStateEnabled != 0
+}
+
+func (s *OutputState) Mode() (mode OutputMode, ok bool) {
+	return s.mode, s.fields&outputStateMode != 0
+}
+
+func (s *OutputState) CustomMode() (width, height, refresh int32, ok bool) {
+	return s.customWidth, s.customHeight, s.customRate, s.fields&outputStateCustomMode != 0
+}
+
+func (s *OutputState) Scale() (scale float32, ok bool) {
+	return s.scale, s.fields&outputStateScale != 0
+}
+
+func (s *OutputState) Transform() (transform OutputTransform, ok bool) {
+	return s.transform, s.fields&outputStateTransform != 0
+}
+
+func (s *OutputState) AdaptiveSyncEnabled() (enabled, ok bool) {
+	return s.adaptiveSync, s.fields&outputStateAdaptiveSync != 0
+}
+
+func (s *OutputState) RenderFormat() (format uint32, ok bool) {
+	return s.renderFormat, s.fields&outputStateRenderFormat != 0
+}
+
+// toC builds a wlroots output state from s. The returned state must
+// be freed with wlr_output_state_finish.
+func (s *OutputState) toC() (cs C.struct_wlr_output_state) {
+	C.wlr_output_state_init(&cs)
+	if s == nil {
+		return cs
+	}
+
+	if s.fields&outputStateEnabled != 0 {
+		C.wlr_output_state_set_enabled(&cs, C.bool(s.enabled))
+	}
+	if s.fields&outputStateMode != 0 {
+		C.wlr_output_state_set_mode(&cs, s.mode.p)
+	}
+	if s.fields&outputStateCustomMode != 0 {
+		C.wlr_output_state_set_custom_mode(&cs, C.int32_t(s.customWidth), C.int32_t(s.customHeight), C.int32_t(s.customRate))
+	}
+	if s.fields&outputStateScale != 0 {
+		C.wlr_output_state_set_scale(&cs, C.float(s.scale))
+	}
+	if s.fields&outputStateTransform != 0 {
+		C.wlr_output_state_set_transform(&cs, C.enum_wl_output_transform(s.transform))
+	}
+	if s.fields&outputStateAdaptiveSync != 0 {
+		C.wlr_output_state_set_adaptive_sync_enabled(&cs, C.bool(s.adaptiveSync))
+	}
+	if s.fields&outputStateRenderFormat != 0 {
+		C.wlr_output_state_set_render_format(&cs, C.uint32_t(s.renderFormat))
+	}
+	if s.fields&outputStateDamage != 0 && s.damage.Valid() {
+		C.wlr_output_state_set_damage(&cs, s.damage.p)
+	}
+
+	return cs
+}
+
 type OutputTransform int
 
 const (
@@ -0,0 +1,131 @@
+package wlr
+
+//go:generate sh -c "wayland-scanner server-header $(pkg-config --variable=pkgdatadir wayland-protocols)/unstable/pointer-constraints/pointer-constraints-unstable-v1.xml pointer-constraints-unstable-v1-protocol.h"
+
+/*
+#include <wlr/types/wlr_pointer_constraints_v1.h>
+*/
+import "C"
+
+import "unsafe"
+
+// PointerConstraintsV1 implements the pointer-constraints-unstable-v1
+// protocol, which lets clients lock the pointer in place or confine it
+// to a region of a surface. Constraints are not enforced
+// automatically; the compositor must activate the constraint for the
+// focused surface and limit cursor motion accordingly.
+type PointerConstraintsV1 struct {
+	p *C.struct_wlr_pointer_constraints_v1
+}
+
+func CreatePointerConstraintsV1(display Display) PointerConstraintsV1 {
+	checkThread()
+
+	p := C.wlr_pointer_constraints_v1_create(display.p)
+	return PointerConstraintsV1{p: p}
+}
+
+func (c PointerConstraintsV1) Valid() bool {
+	return c.p != nil
+}
+
+func (c PointerConstraintsV1) OnNewConstraint(cb func(PointerConstraintV1)) Listener {
+	return newListener(&c.p.events.new_constraint, func(lis Listener, data unsafe.Pointer) {
+		cb(PointerConstraintV1{p: (*C.struct_wlr_pointer_constraint_v1)(data)})
+	})
+}
+
+// ConstraintForSurface returns the constraint that the client has
+// requested for surface on seat. It is invalid if there is none.
+func (c PointerConstraintsV1) ConstraintForSurface(surface Surface, seat Seat) PointerConstraintV1 {
+	p := C.wlr_pointer_constraints_v1_constraint_for_surface(c.p, surface.p, seat.p)
+	return PointerConstraintV1{p: p}
+}
+
+type PointerConstraintV1Type uint32
+
+const (
+	PointerConstraintV1Locked   PointerConstraintV1Type = C.WLR_POINTER_CONSTRAINT_V1_LOCKED
+	PointerConstraintV1Confined PointerConstraintV1Type = C.WLR_POINTER_CONSTRAINT_V1_CONFINED
+)
+
+type PointerConstraintV1Lifetime uint32
+
+const (
+	PointerConstraintV1LifetimeOneshot    PointerConstraintV1Lifetime = C.ZWP_POINTER_CONSTRAINTS_V1_LIFETIME_ONESHOT
+	PointerConstraintV1LifetimePersistent PointerConstraintV1Lifetime = C.ZWP_POINTER_CONSTRAINTS_V1_LIFETIME_PERSISTENT
+)
+
+type PointerConstraintV1 struct {
+	p *C.struct_wlr_pointer_constraint_v1
+}
+
+func (c PointerConstraintV1) Valid() bool {
+	return c.p != nil
+}
+
+func (c PointerConstraintV1) OnDestroy(cb func(PointerConstraintV1)) Listener {
+	return newListener(&c.p.events.destroy, func(lis Listener, data unsafe.Pointer) {
+		cb(c)
+	})
+}
+
+// OnSetRegion is called when the client changes the region of the
+// constraint.
+func (c PointerConstraintV1) OnSetRegion(cb func(PointerConstraintV1)) Listener {
+	return newListener(&c.p.events.set_region, func(lis Listener, data unsafe.Pointer) {
+		cb(c)
+	})
+}
+
+func (c PointerConstraintV1) Type() PointerConstraintV1Type {
+	return PointerConstraintV1Type(c.p._type)
+}
+
+// Lifetime returns whether the constraint is destroyed when it is
+// deactivated or can be activated again.
+func (c PointerConstraintV1) Lifetime() PointerConstraintV1Lifetime {
+	return PointerConstraintV1Lifetime(c.p.lifetime)
+}
+
+func (c PointerConstraintV1) Surface() Surface {
+	return Surface{p: c.p.surface}
+}
+
+func (c PointerConstraintV1) Seat() Seat {
+	return Seat{p: c.p.seat}
+}
+
+// Region returns the area of the surface, in surface-local
+// coordinates, that the pointer is constrained to. It is the
+// intersection of the region requested by the client and the
+// surface's input region. The returned Region belongs to the
+// constraint and must not be modified or destroyed.
+func (c PointerConstraintV1) Region() Region {
+	return Region{p: &c.p.region}
+}
+
+// CursorHint returns the position, in surface-local coordinates, at
+// which the client would like the cursor to appear when a locked
+// pointer is unlocked, if it has provided one.
+func (c PointerConstraintV1) CursorHint() (x, y float64, ok bool) {
+	ok = c.p.current.committed&C.WLR_POINTER_CONSTRAINT_V1_STATE_CURSOR_HINT != 0
+	return float64(c.p.current.cursor_hint.x), float64(c.p.current.cursor_hint.y), ok
+}
+
+// SendActivated notifies the client that the constraint is now in
+// effect. It should be called when the constrained surface gains
+// pointer focus.
+func (c PointerConstraintV1) SendActivated() {
+	checkThread()
+
+	C.wlr_pointer_constraint_v1_send_activated(c.p)
+}
+
+// SendDeactivated notifies the client that the constraint is no longer
+// in effect. Oneshot constraints are destroyed by this.
+func (c PointerConstraintV1) SendDeactivated() {
+	checkThread()
+
+	C.wlr_pointer_constraint_v1_send_deactivated(c.p)
+}
@@ -0,0 +1,84 @@
+package wlr
+
+/*
+#include <wlr/types/wlr_pointer_gestures_v1.h>
+*/
+import "C"
+
+import (
+	"time"
+	"unsafe"
+)
+
+// PointerGesturesV1 implements the pointer-gestures-unstable-v1
+// protocol. Gestures are not forwarded automatically; the events from
+// Cursor.OnSwipeBegin and friends should be passed to the matching
+// Send methods, which send them to the seat's focused pointer client.
+type PointerGesturesV1 struct {
+	p *C.struct_wlr_pointer_gestures_v1
+}
+
+func CreatePointerGesturesV1(display Display) PointerGesturesV1 {
+	checkThread()
+
+	p := C.wlr_pointer_gestures_v1_create(display.p)
+	return PointerGesturesV1{p: p}
+}
+
+func (g PointerGesturesV1) Valid() bool {
+	return g.p != nil
+}
+
+func (g PointerGesturesV1) OnDestroy(cb func(PointerGesturesV1)) Listener {
+	return newListener(&g.p.events.destroy, func(lis Listener, data unsafe.Pointer) {
+		cb(g)
+	})
+}
+
+func (g PointerGesturesV1) SendSwipeBegin(seat Seat, time time.Time, fingers uint32) {
+	checkThread()
+
+	C.wlr_pointer_gestures_v1_send_swipe_begin(g.p, seat.p, C.uint32_t(time.UnixMilli()), C.uint32_t(fingers))
+}
+
+func (g PointerGesturesV1) SendSwipeUpdate(seat Seat, time time.Time, dx, dy float64) {
+	checkThread()
+
+	C.wlr_pointer_gestures_v1_send_swipe_update(g.p, seat.p, C.uint32_t(time.UnixMilli()), C.double(dx), C.double(dy))
+}
+
+func (g PointerGesturesV1) SendSwipeEnd(seat Seat, time time.Time, cancelled bool) {
+	checkThread()
+
+	C.wlr_pointer_gestures_v1_send_swipe_end(g.p, seat.p, C.uint32_t(time.UnixMilli()), C.bool(cancelled))
+}
+
+func (g PointerGesturesV1) SendPinchBegin(seat Seat, time time.Time, fingers uint32) {
+	checkThread()
+
+	C.wlr_pointer_gestures_v1_send_pinch_begin(g.p, seat.p, C.uint32_t(time.UnixMilli()), C.uint32_t(fingers))
+}
+
+func (g PointerGesturesV1) SendPinchUpdate(seat Seat, time time.Time, dx, dy, scale, rotation float64) {
+	checkThread()
+
+	C.wlr_pointer_gestures_v1_send_pinch_update(g.p, seat.p, C.uint32_t(time.UnixMilli()), C.double(dx), C.double(dy), C.double(scale), C.double(rotation))
+}
+
+func (g PointerGesturesV1) SendPinchEnd(seat Seat, time time.Time, cancelled bool) {
+	checkThread()
+
+	C.wlr_pointer_gestures_v1_send_pinch_end(g.p, seat.p, C.uint32_t(time.UnixMilli()), C.bool(cancelled))
+}
+
+func (g PointerGesturesV1) SendHoldBegin(seat Seat, time time.Time, fingers uint32) {
+	checkThread()
+
+	C.wlr_pointer_gestures_v1_send_hold_begin(g.p, seat.p, C.uint32_t(time.UnixMilli()), C.uint32_t(fingers))
+}
+
+func (g PointerGesturesV1) SendHoldEnd(seat Seat, time time.Time, cancelled bool) {
+	checkThread()
+
+	C.wlr_pointer_gestures_v1_send_hold_end(g.p, seat.p, C.uint32_t(time.UnixMilli()), C.bool(cancelled))
+}
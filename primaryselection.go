@@ -11,6 +11,8 @@ type PrimarySelectionV1DeviceManager struct {
 }
 
 func CreatePrimarySelectionV1DeviceManager(display Display) PrimarySelectionV1DeviceManager {
+	checkThread()
+
 	p := C.wlr_primary_selection_v1_device_manager_create(display.p)
 	return PrimarySelectionV1DeviceManager{p: p}
 }
@@ -0,0 +1,256 @@
+package wlr
+
+/*
+#include <stdlib.h>
+#include <pixman.h>
+#include <wlr/util/region.h>
+*/
+import "C"
+
+import (
+	"image"
+	"iter"
+	"unsafe"
+)
+
+// Region is a set of rectangles, such as the area of an output that
+// needs to be redrawn. It is backed by a pixman region and must be
+// destroyed when it is no longer needed.
+//
+// The zero value is not a valid Region, but it can be read from and
+// used as the argument of other methods as if it were empty. Methods
+// that modify a Region panic if it is the zero value. Elsewhere in
+// the package, functions that accept a Region generally treat an
+// invalid one as meaning that the region is unspecified, such as
+// damage covering an entire output.
+type Region struct {
+	p *C.pixman_region32_t
+}
+
+func newRegion() Region {
+	p := (*C.pixman_region32_t)(C.malloc(C.sizeof_pixman_region32_t))
+	return Region{p: p}
+}
+
+// NewRegion returns a new, empty Region.
+func NewRegion() Region {
+	r := newRegion()
+	C.pixman_region32_init(r.p)
+	return r
+}
+
+// NewRegionRect returns a new Region containing rect.
+func NewRegionRect(rect image.Rectangle) Region {
+	rect = rect.Canon()
+	r := newRegion()
+	C.pixman_region32_init_rect(
+		r.p,
+		C.int(rect.Min.X),
+		C.int(rect.Min.Y),
+		C.uint(rect.Dx()),
+		C.uint(rect.Dy()),
+	)
+	return r
+}
+
+func (r Region) Valid() bool {
+	return r.p != nil
+}
+
+// mustBeValid panics if r is the zero Region. It should be called by
+// methods that modify r, as pixman would otherwise crash.
+func (r Region) mustBeValid() {
+	if r.p == nil {
+		panic("wlr: modification of zero Region")
+	}
+}
+
+// Destroy frees the region. It does nothing if r is the zero Region.
+func (r Region) Destroy() {
+	if r.p == nil {
+		return
+	}
+
+	C.pixman_region32_fini(r.p)
+	C.free(unsafe.Pointer(r.p))
+}
+
+// Copy returns a new Region containing the same rectangles as r.
+func (r Region) Copy() Region {
+	c := NewRegion()
+	if r.p != nil {
+		C.pixman_region32_copy(c.p, r.p)
+	}
+	return c
+}
+
+// Clear empties the region.
+func (r Region) Clear() {
+	r.mustBeValid()
+	C.pixman_region32_clear(r.p)
+}
+
+func (r Region) Empty() bool {
+	if r.p == nil {
+		return true
+	}
+
+	return C.pixman_region32_not_empty(r.p) == 0
+}
+
+// Extents returns the smallest rectangle that contains the entire
+// region.
+func (r Region) Extents() image.Rectangle {
+	if r.p == nil {
+		return image.Rectangle{}
+	}
+
+	return regionBoxToRect(C.pixman_region32_extents(r.p))
+}
+
+// Rects yields the non-overlapping rectangles that make up the
+// region.
+func (r Region) Rects() iter.Seq[image.Rectangle] {
+	return func(yield func(image.Rectangle) bool) {
+		if r.p == nil {
+			return
+		}
+
+		var n C.int
+		boxes := C.pixman_region32_rectangles(r.p, &n)
+		for _, box := range unsafe.Slice(boxes, n) {
+			if !yield(regionBoxToRect(&box)) {
+				return
+			}
+		}
+	}
+}
+
+// AddRect adds rect to the region.
+func (r Region) AddRect(rect image.Rectangle) {
+	r.mustBeValid()
+
+	rect = rect.Canon()
+	C.pixman_region32_union_rect(
+		r.p,
+		r.p,
+		C.int(rect.Min.X),
+		C.int(rect.Min.Y),
+		C.uint(rect.Dx()),
+		C.uint(rect.Dy()),
+	)
+}
+
+// Equal returns true if r and other contain exactly the same area.
+func (r Region) Equal(other Region) bool {
+	if r.p == nil || other.p == nil {
+		return r.Empty() && other.Empty()
+	}
+
+	return C.pixman_region32_equal(r.p, other.p) != 0
+}
+
+// ContainsPoint returns true if the point (x, y) is inside of the
+// region.
+func (r Region) ContainsPoint(x, y int) bool {
+	if r.p == nil {
+		return false
+	}
+
+	return C.pixman_region32_contains_point(r.p, C.int(x), C.int(y), nil) != 0
+}
+
+// Union sets r to the union of r and other.
+func (r Region) Union(other Region) {
+	r.mustBeValid()
+	if other.p == nil {
+		return
+	}
+
+	C.pixman_region32_union(r.p, r.p, other.p)
+}
+
+// Intersect sets r to the intersection of r and other.
+func (r Region) Intersect(other Region) {
+	r.mustBeValid()
+	if other.p == nil {
+		r.Clear()
+		return
+	}
+
+	C.pixman_region32_intersect(r.p, r.p, other.p)
+}
+
+// IntersectRect sets r to the intersection of r and rect.
+func (r Region) IntersectRect(rect image.Rectangle) {
+	r.mustBeValid()
+
+	rect = rect.Canon()
+	C.pixman_region32_intersect_rect(
+		r.p,
+		r.p,
+		C.int(rect.Min.X),
+		C.int(rect.Min.Y),
+		C.uint(rect.Dx()),
+		C.uint(rect.Dy()),
+	)
+}
+
+// Subtract removes the area of other from r.
+func (r Region) Subtract(other Region) {
+	r.mustBeValid()
+	if other.p == nil {
+		return
+	}
+
+	C.pixman_region32_subtract(r.p, r.p, other.p)
+}
+
+// SubtractRect removes rect from r.
+func (r Region) SubtractRect(rect image.Rectangle) {
+	r.mustBeValid()
+
+	other := NewRegionRect(rect)
+	defer other.Destroy()
+
+	r.Subtract(other)
+}
+
+// Translate moves the region by (dx, dy).
+func (r Region) Translate(dx, dy int) {
+	r.mustBeValid()
+	C.pixman_region32_translate(r.p, C.int(dx), C.int(dy))
+}
+
+// Scale scales the region by scale, rounding outwards so that the
+// scaled region covers at least the area of the original.
+func (r Region) Scale(scale float32) {
+	r.mustBeValid()
+	C.wlr_region_scale(r.p, r.p, C.float(scale))
+}
+
+// ScaleXY is like Scale but scales the x and y axes separately.
+func (r Region) ScaleXY(scaleX, scaleY float32) {
+	r.mustBeValid()
+	C.wlr_region_scale_xy(r.p, r.p, C.float(scaleX), C.float(scaleY))
+}
+
+// Transform applies transform to the region, which is within a
+// rectangle of the given width and height, such as converting damage
+// from layout coordinates to the buffer coordinates of a rotated
+// output.
+func (r Region) Transform(transform OutputTransform, width, height int) {
+	r.mustBeValid()
+	C.wlr_region_transform(r.p, r.p, C.enum_wl_output_transform(transform), C.int(width), C.int(height))
+}
+
+// Expand grows each rectangle in the region outwards by distance on
+// every side.
+func (r Region) Expand(distance int) {
+	r.mustBeValid()
+	C.wlr_region_expand(r.p, r.p, C.int(distance))
+}
+
+func regionBoxToRect(box *C.pixman_box32_t) image.Rectangle {
+	return image.Rect(int(box.x1), int(box.y1), int(box.x2), int(box.y2))
+}
@@ -0,0 +1,53 @@
+package wlr
+
+/*
+#include <wlr/types/wlr_relative_pointer_v1.h>
+*/
+import "C"
+
+import (
+	"time"
+	"unsafe"
+)
+
+// RelativePointerManagerV1 implements the
+// relative-pointer-unstable-v1 protocol, which lets clients receive
+// pointer motion that is not limited by the edges of the screen, such
+// as while the pointer is locked by a PointerConstraintV1.
+type RelativePointerManagerV1 struct {
+	p *C.struct_wlr_relative_pointer_manager_v1
+}
+
+func CreateRelativePointerManagerV1(display Display) RelativePointerManagerV1 {
+	checkThread()
+
+	p := C.wlr_relative_pointer_manager_v1_create(display.p)
+	return RelativePointerManagerV1{p: p}
+}
+
+func (m RelativePointerManagerV1) Valid() bool {
+	return m.p != nil
+}
+
+func (m RelativePointerManagerV1) OnDestroy(cb func(RelativePointerManagerV1)) Listener {
+	return newListener(&m.p.events.destroy, func(lis Listener, data unsafe.Pointer) {
+		cb(m)
+	})
+}
+
+// SendRelativeMotion sends relative motion to the client with pointer
+// focus on seat. It should be called for every motion event, including
+// those that do not move the cursor because the pointer is locked.
+func (m RelativePointerManagerV1) SendRelativeMotion(seat Seat, time time.Time, dx, dy, unaccelDX, unaccelDY float64) {
+	checkThread()
+
+	C.wlr_relative_pointer_manager_v1_send_relative_motion(
+		m.p,
+		seat.p,
+		C.uint64_t(time.UnixMicro()),
+		C.double(dx),
+		C.double(dy),
+		C.double(unaccelDX),
+		C.double(unaccelDY),
+	)
+}
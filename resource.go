@@ -0,0 +1,294 @@
+package wlr
+
+/*
+#include <stdlib.h>
+#include <string.h>
+#include <wayland-server-core.h>
+
+extern int _resource_dispatch_cb(uintptr_t handle, struct wl_resource *resource, uint32_t opcode, struct wl_message *msg, union wl_argument *args);
+extern void _resource_destroy_cb(struct wl_resource *resource);
+
+static int _resource_dispatcher(const void *impl, void *target, uint32_t opcode, const struct wl_message *msg, union wl_argument *args) {
+	return _resource_dispatch_cb((uintptr_t)impl, (struct wl_resource *)target, opcode, (struct wl_message *)msg, args);
+}
+
+static inline void _wl_resource_set_dispatcher(struct wl_resource *resource, uintptr_t handle) {
+	wl_resource_set_dispatcher(resource, _resource_dispatcher, (const void *)handle, (void *)handle, _resource_destroy_cb);
+}
+
+static inline uintptr_t _wl_resource_get_handle(struct wl_resource *resource) {
+	return (uintptr_t)wl_resource_get_user_data(resource);
+}
+
+static inline void _wl_resource_post_error(struct wl_resource *resource, uint32_t code, const char *msg) {
+	wl_resource_post_error(resource, code, "%s", msg);
+}
+
+static inline int32_t _wl_argument_get_i(union wl_argument *args, int i) { return args[i].i; }
+static inline uint32_t _wl_argument_get_u(union wl_argument *args, int i) { return args[i].u; }
+static inline double _wl_argument_get_f(union wl_argument *args, int i) { return wl_fixed_to_double(args[i].f); }
+static inline const char *_wl_argument_get_s(union wl_argument *args, int i) { return args[i].s; }
+static inline struct wl_resource *_wl_argument_get_o(union wl_argument *args, int i) { return (struct wl_resource *)args[i].o; }
+static inline uint32_t _wl_argument_get_n(union wl_argument *args, int i) { return args[i].n; }
+static inline struct wl_array *_wl_argument_get_a(union wl_argument *args, int i) { return args[i].a; }
+static inline int32_t _wl_argument_get_h(union wl_argument *args, int i) { return args[i].h; }
+
+static inline void _wl_argument_set_i(union wl_argument *args, int i, int32_t v) { args[i].i = v; }
+static inline void _wl_argument_set_u(union wl_argument *args, int i, uint32_t v) { args[i].u = v; }
+static inline void _wl_argument_set_f(union wl_argument *args, int i, double v) { args[i].f = wl_fixed_from_double(v); }
+static inline void _wl_argument_set_s(union wl_argument *args, int i, const char *v) { args[i].s = v; }
+static inline void _wl_argument_set_o(union wl_argument *args, int i, struct wl_resource *v) { args[i].o = (struct wl_object *)v; }
+static inline void _wl_argument_set_a(union wl_argument *args, int i, struct wl_array *v) { args[i].a = v; }
+static inline void _wl_argument_set_h(union wl_argument *args, int i, int32_t v) { args[i].h = v; }
+
+static inline struct wl_array *_wl_array_new(void *data, size_t size) {
+	struct wl_array *array = malloc(sizeof(*array));
+	wl_array_init(array);
+	memcpy(wl_array_add(array, size), data, size);
+	return array;
+}
+
+static inline void _wl_array_free(struct wl_array *array) {
+	wl_array_release(array);
+	free(array);
+}
+*/
+import "C"
+
+import (
+	"fmt"
+	"runtime/cgo"
+	"unsafe"
+)
+
+// NewID is the type of new_id arguments in requests dispatched to a
+// RequestFunc. It is the ID that should be passed to CreateResource
+// to create the new object.
+type NewID uint32
+
+// Fd is the type of fd arguments in requests and events. File
+// descriptors received in requests are owned by the receiver.
+type Fd int32
+
+// RequestFunc handles a request sent by a client to a resource. The
+// arguments are decoded according to the request's signature:
+//
+//	i: int32
+//	u: uint32
+//	f: float64
+//	s: string
+//	o: Resource
+//	n: NewID
+//	a: []byte
+//	h: Fd
+//
+// Nullable strings and objects that are null are passed as the zero
+// value of their type.
+type RequestFunc func(r Resource, opcode uint32, args []any)
+
+type resourceImpl struct {
+	dispatch RequestFunc
+	destroy  func(Resource)
+}
+
+type Resource struct {
+	p *C.struct_wl_resource
+}
+
+// CreateResource creates a new resource for client. Requests sent to
+// the resource are ignored until SetDispatcher is called.
+func CreateResource(client Client, iface *Interface, version, id uint32) Resource {
+	checkThread()
+
+	p := C.wl_resource_create(client.p, iface.toC(), C.int(version), C.uint32_t(id))
+	if p == nil {
+		return Resource{}
+	}
+
+	handle := cgo.NewHandle(&resourceImpl{})
+	C._wl_resource_set_dispatcher(p, C.uintptr_t(handle))
+	return Resource{p: p}
+}
+
+func (r Resource) Valid() bool {
+	return r.p != nil
+}
+
+func (r Resource) GetClient() Client {
+	return Client{p: C.wl_resource_get_client(r.p)}
+}
+
+func (r Resource) ID() uint32 {
+	return uint32(C.wl_resource_get_id(r.p))
+}
+
+func (r Resource) Version() uint32 {
+	return uint32(C.wl_resource_get_version(r.p))
+}
+
+func (r Resource) Class() string {
+	return C.GoString(C.wl_resource_get_class(r.p))
+}
+
+func (r Resource) Destroy() {
+	C.wl_resource_destroy(r.p)
+}
+
+func (r Resource) OnDestroy(cb func(Resource)) Listener {
+	lis := newListener(nil, func(lis Listener, data unsafe.Pointer) {
+		cb(r)
+	})
+	C.wl_resource_add_destroy_listener(r.p, &lis.p.lis)
+	return lis
+}
+
+// SetDispatcher sets the functions that handle requests to the
+// resource and its destruction. destroy may be nil. This should only
+// be called on resources created with CreateResource. Calling it
+// again replaces the previous functions.
+func (r Resource) SetDispatcher(dispatch RequestFunc, destroy func(Resource)) {
+	old := cgo.Handle(C._wl_resource_get_handle(r.p))
+	handle := cgo.NewHandle(&resourceImpl{
+		dispatch: dispatch,
+		destroy:  destroy,
+	})
+	C._wl_resource_set_dispatcher(r.p, C.uintptr_t(handle))
+	old.Delete()
+}
+
+// PostEvent sends the event with the given opcode to the client. The
+// arguments are encoded in the same way that RequestFunc decodes
+// them, except that new_id arguments should be passed as the newly
+// created Resource. A nil argument is sent as a null string or
+// object.
+func (r Resource) PostEvent(opcode uint32, args ...any) {
+	checkThread()
+
+	var cargs *C.union_wl_argument
+	if len(args) > 0 {
+		cargs = (*C.union_wl_argument)(C.calloc(C.size_t(len(args)), C.sizeof_union_wl_argument))
+		defer C.free(unsafe.Pointer(cargs))
+	}
+
+	for i, arg := range args {
+		ci := C.int(i)
+		switch arg := arg.(type) {
+		case nil:
+			C._wl_argument_set_o(cargs, ci, nil)
+		case int32:
+			C._wl_argument_set_i(cargs, ci, C.int32_t(arg))
+		case uint32:
+			C._wl_argument_set_u(cargs, ci, C.uint32_t(arg))
+		case float64:
+			C._wl_argument_set_f(cargs, ci, C.double(arg))
+		case string:
+			s := C.CString(arg)
+			defer C.free(unsafe.Pointer(s))
+			C._wl_argument_set_s(cargs, ci, s)
+		case Resource:
+			C._wl_argument_set_o(cargs, ci, arg.p)
+		case []byte:
+			var data unsafe.Pointer
+			if len(arg) > 0 {
+				data = C.CBytes(arg)
+				defer C.free(data)
+			}
+			a := C._wl_array_new(data, C.size_t(len(arg)))
+			defer C._wl_array_free(a)
+			C._wl_argument_set_a(cargs, ci, a)
+		case Fd:
+			C._wl_argument_set_h(cargs, ci, C.int32_t(arg))
+		default:
+			panic(fmt.Errorf("unsupported event argument type %T", arg))
+		}
+	}
+
+	C.wl_resource_post_event_array(r.p, C.uint32_t(opcode), cargs)
+}
+
+func (r Resource) PostError(code uint32, msg string) {
+	checkThread()
+
+	s := C.CString(msg)
+	defer C.free(unsafe.Pointer(s))
+
+	C._wl_resource_post_error(r.p, C.uint32_t(code), s)
+}
+
+func (r Resource) PostNoMemory() {
+	checkThread()
+
+	C.wl_resource_post_no_memory(r.p)
+}
+
+func decodeArgs(msg *C.struct_wl_message, cargs *C.union_wl_argument) []any {
+	var args []any
+	for _, c := range C.GoString(msg.signature) {
+		i := C.int(len(args))
+		switch c {
+		case 'i':
+			args = append(args, int32(C._wl_argument_get_i(cargs, i)))
+		case 'u':
+			args = append(args, uint32(C._wl_argument_get_u(cargs, i)))
+		case 'f':
+			args = append(args, float64(C._wl_argument_get_f(cargs, i)))
+		case 's':
+			args = append(args, C.GoString(C._wl_argument_get_s(cargs, i)))
+		case 'o':
+			args = append(args, Resource{p: C._wl_argument_get_o(cargs, i)})
+		case 'n':
+			args = append(args, NewID(C._wl_argument_get_n(cargs, i)))
+		case 'a':
+			var data []byte
+			if a := C._wl_argument_get_a(cargs, i); a != nil && a.size > 0 {
+				data = C.GoBytes(a.data, C.int(a.size))
+			}
+			args = append(args, data)
+		case 'h':
+			args = append(args, Fd(C._wl_argument_get_h(cargs, i)))
+		}
+	}
+	return args
+}
+
+//export _resource_dispatch_cb
+func _resource_dispatch_cb(handle C.uintptr_t, p *C.struct_wl_resource, opcode C.uint32_t, msg *C.struct_wl_message, cargs *C.union_wl_argument) C.int {
+	impl := cgo.Handle(handle).Value().(*resourceImpl)
+	if impl.dispatch != nil {
+		impl.dispatch(Resource{p: p}, uint32(opcode), decodeArgs(msg, cargs))
+	}
+	return 0
+}
+
+//export _resource_destroy_cb
+func _resource_destroy_cb(p *C.struct_wl_resource) {
+	handle := cgo.Handle(C._wl_resource_get_handle(p))
+	defer handle.Delete()
+
+	impl := handle.Value().(*resourceImpl)
+	if impl.destroy != nil {
+		impl.destroy(Resource{p: p})
+	}
+}
+
+type Client struct {
+	p *C.struct_wl_client
+}
+
+func (c Client) GetCredentials() (pid, uid, gid int) {
+	var cpid C.pid_t
+	var cuid C.uid_t
+	var cgid C.gid_t
+	C.wl_client_get_credentials(c.p, &cpid, &cuid, &cgid)
+	return int(cpid), int(cuid), int(cgid)
+}
+
+func (c Client) Destroy() {
+	C.wl_client_destroy(c.p)
+}
+
+func (c Client) PostNoMemory() {
+	checkThread()
+
+	C.wl_client_post_no_memory(c.p)
+}
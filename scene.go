@@ -0,0 +1,433 @@
+package wlr
+
+/*
+#include <wlr/types/wlr_scene.h>
+*/
+import "C"
+
+import (
+	"errors"
+	"image"
+	"image/color"
+	"iter"
+	"time"
+	"unsafe"
+)
+
+type SceneNodeType uint32
+
+const (
+	SceneNodeTree   SceneNodeType = C.WLR_SCENE_NODE_TREE
+	SceneNodeRect   SceneNodeType = C.WLR_SCENE_NODE_RECT
+	SceneNodeBuffer SceneNodeType = C.WLR_SCENE_NODE_BUFFER
+)
+
+// Scene is the root of a scene graph. Surfaces and other content are
+// added to the scene as nodes and rendered to outputs by SceneOutput,
+// which handles damage tracking and stacking automatically.
+type Scene struct {
+	p *C.struct_wlr_scene
+}
+
+func CreateScene() Scene {
+	checkThread()
+
+	p := C.wlr_scene_create()
+	return Scene{p: p}
+}
+
+func (s Scene) Valid() bool {
+	return s.p != nil
+}
+
+// Tree returns the root tree of the scene. Destroying the root tree's
+// node destroys the entire scene.
+func (s Scene) Tree() SceneTree {
+	return SceneTree{p: &s.p.tree}
+}
+
+func (s Scene) AttachOutputLayout(layout OutputLayout) SceneOutputLayout {
+	p := C.wlr_scene_attach_output_layout(s.p, layout.p)
+	return SceneOutputLayout{p: p}
+}
+
+// Output returns the SceneOutput associated with output, if any.
+func (s Scene) Output(output Output) SceneOutput {
+	p := C.wlr_scene_get_scene_output(s.p, output.p)
+	return SceneOutput{p: p}
+}
+
+type SceneNode struct {
+	p *C.struct_wlr_scene_node
+}
+
+func (n SceneNode) Valid() bool {
+	return n.p != nil
+}
+
+func (n SceneNode) Destroy() {
+	C.wlr_scene_node_destroy(n.p)
+}
+
+func (n SceneNode) OnDestroy(cb func(SceneNode)) Listener {
+	return newListener(&n.p.events.destroy, func(lis Listener, data unsafe.Pointer) {
+		cb(n)
+	})
+}
+
+func (n SceneNode) Type() SceneNodeType {
+	return SceneNodeType(n.p._type)
+}
+
+func (n SceneNode) Parent() SceneTree {
+	return SceneTree{p: n.p.parent}
+}
+
+func (n SceneNode) Enabled() bool {
+	return bool(n.p.enabled)
+}
+
+func (n SceneNode) SetEnabled(enabled bool) {
+	C.wlr_scene_node_set_enabled(n.p, C.bool(enabled))
+}
+
+// Position returns the position of the node relative to its parent.
+func (n SceneNode) Position() (x, y int) {
+	return int(n.p.x), int(n.p.y)
+}
+
+func (n SceneNode) SetPosition(x, y int) {
+	C.wlr_scene_node_set_position(n.p, C.int(x), C.int(y))
+}
+
+// Coords returns the position of the node in layout coordinates. ok
+// is false if the node or any of its ancestors are disabled.
+func (n SceneNode) Coords() (lx, ly int, ok bool) {
+	var clx, cly C.int
+	ok = bool(C.wlr_scene_node_coords(n.p, &clx, &cly))
+	return int(clx), int(cly), ok
+}
+
+func (n SceneNode) PlaceAbove(sibling SceneNode) {
+	C.wlr_scene_node_place_above(n.p, sibling.p)
+}
+
+func (n SceneNode) PlaceBelow(sibling SceneNode) {
+	C.wlr_scene_node_place_below(n.p, sibling.p)
+}
+
+func (n SceneNode) RaiseToTop() {
+	C.wlr_scene_node_raise_to_top(n.p)
+}
+
+func (n SceneNode) LowerToBottom() {
+	C.wlr_scene_node_lower_to_bottom(n.p)
+}
+
+func (n SceneNode) Reparent(parent SceneTree) {
+	C.wlr_scene_node_reparent(n.p, parent.p)
+}
+
+// At finds the topmost enabled node in n's subtree that intersects
+// the given point, which is in layout coordinates. It returns the
+// node along with the point translated into the found node's
+// coordinate space.
+func (n SceneNode) At(lx, ly float64) (node SceneNode, nx, ny float64, ok bool) {
+	var cnx, cny C.double
+	p := C.wlr_scene_node_at(n.p, C.double(lx), C.double(ly), &cnx, &cny)
+	return SceneNode{p: p}, float64(cnx), float64(cny), p != nil
+}
+
+// Tree returns the node as a SceneTree. If the node is not a tree,
+// the returned value is invalid.
+func (n SceneNode) Tree() SceneTree {
+	if n.Type() != SceneNodeTree {
+		return SceneTree{}
+	}
+
+	// The node is the first field of the tree, so this is safe.
+	return SceneTree{p: (*C.struct_wlr_scene_tree)(unsafe.Pointer(n.p))}
+}
+
+// Rect returns the node as a SceneRect. If the node is not a rect,
+// the returned value is invalid.
+func (n SceneNode) Rect() SceneRect {
+	if n.Type() != SceneNodeRect {
+		return SceneRect{}
+	}
+
+	return SceneRect{p: (*C.struct_wlr_scene_rect)(unsafe.Pointer(n.p))}
+}
+
+// Buffer returns the node as a SceneBuffer. If the node is not a
+// buffer, the returned value is invalid.
+func (n SceneNode) Buffer() SceneBuffer {
+	if n.Type() != SceneNodeBuffer {
+		return SceneBuffer{}
+	}
+
+	return SceneBuffer{p: C.wlr_scene_buffer_from_node(n.p)}
+}
+
+type SceneTree struct {
+	p *C.struct_wlr_scene_tree
+}
+
+func CreateSceneTree(parent SceneTree) SceneTree {
+	checkThread()
+
+	p := C.wlr_scene_tree_create(parent.p)
+	return SceneTree{p: p}
+}
+
+func (t SceneTree) Valid() bool {
+	return t.p != nil
+}
+
+func (t SceneTree) Node() SceneNode {
+	return SceneNode{p: &t.p.node}
+}
+
+// Children yields the direct children of the tree from bottom to
+// top.
+func (t SceneTree) Children() iter.Seq[SceneNode] {
+	offset := int(unsafe.Offsetof(C.struct_wlr_scene_node{}.link))
+	return func(yield func(SceneNode) bool) {
+		seq := listSeq[C.struct_wlr_scene_node](&t.p.children, offset)
+		for node := range seq {
+			if !yield(SceneNode{p: node}) {
+				return
+			}
+		}
+	}
+}
+
+type SceneRect struct {
+	p *C.struct_wlr_scene_rect
+}
+
+func CreateSceneRect(parent SceneTree, width, height int, c color.Color) SceneRect {
+	checkThread()
+
+	cc := colorToC(c)
+	p := C.wlr_scene_rect_create(parent.p, C.int(width), C.int(height), &cc[0])
+	return SceneRect{p: p}
+}
+
+func (r SceneRect) Valid() bool {
+	return r.p != nil
+}
+
+func (r SceneRect) Node() SceneNode {
+	return SceneNode{p: &r.p.node}
+}
+
+func (r SceneRect) Size() (width, height int) {
+	return int(r.p.width), int(r.p.height)
+}
+
+func (r SceneRect) SetSize(width, height int) {
+	C.wlr_scene_rect_set_size(r.p, C.int(width), C.int(height))
+}
+
+func (r SceneRect) SetColor(c color.Color) {
+	cc := colorToC(c)
+	C.wlr_scene_rect_set_color(r.p, &cc[0])
+}
+
+type SceneBuffer struct {
+	p *C.struct_wlr_scene_buffer
+}
+
+// CreateSceneBuffer creates a node that displays buffer. The buffer
+// may be invalid, in which case the node won't display anything
+// until SetBuffer is called.
+func CreateSceneBuffer(parent SceneTree, buffer Buffer) SceneBuffer {
+	checkThread()
+
+	p := C.wlr_scene_buffer_create(parent.p, buffer.p)
+	return SceneBuffer{p: p}
+}
+
+func (b SceneBuffer) Valid() bool {
+	return b.p != nil
+}
+
+func (b SceneBuffer) Node() SceneNode {
+	return SceneNode{p: &b.p.node}
+}
+
+func (b SceneBuffer) Buffer() Buffer {
+	return Buffer{p: b.p.buffer}
+}
+
+func (b SceneBuffer) SetBuffer(buffer Buffer) {
+	C.wlr_scene_buffer_set_buffer(b.p, buffer.p)
+}
+
+// SetBufferWithDamage is like SetBuffer but only damages the parts of
+// the node covered by damage, in buffer-local coordinates.
+func (b SceneBuffer) SetBufferWithDamage(buffer Buffer, damage Region) {
+	C.wlr_scene_buffer_set_buffer_with_damage(b.p, buffer.p, damage.p)
+}
+
+// SetOpaqueRegion sets the area of the buffer that is known to be
+// opaque, in node-local coordinates, so that content beneath it
+// doesn't need to be rendered. The zero Region is treated as empty.
+func (b SceneBuffer) SetOpaqueRegion(region Region) {
+	if !region.Valid() {
+		region = NewRegion()
+		defer region.Destroy()
+	}
+
+	C.wlr_scene_buffer_set_opaque_region(b.p, region.p)
+}
+
+func (b SceneBuffer) SetDestSize(width, height int) {
+	C.wlr_scene_buffer_set_dest_size(b.p, C.int(width), C.int(height))
+}
+
+func (b SceneBuffer) SetTransform(transform OutputTransform) {
+	C.wlr_scene_buffer_set_transform(b.p, C.enum_wl_output_transform(transform))
+}
+
+func (b SceneBuffer) SetOpacity(opacity float32) {
+	C.wlr_scene_buffer_set_opacity(b.p, C.float(opacity))
+}
+
+func (b SceneBuffer) SendFrameDone(when time.Time) {
+	checkThread()
+
+	ts := timespecToC(when)
+	C.wlr_scene_buffer_send_frame_done(b.p, &ts)
+}
+
+// Surface returns the surface that the buffer is displaying, if the
+// buffer was created for one via one of the surface helpers.
+func (b SceneBuffer) Surface() Surface {
+	ss := C.wlr_scene_surface_try_from_buffer(b.p)
+	if ss == nil {
+		return Surface{}
+	}
+
+	return Surface{p: ss.surface}
+}
+
+// CreateSceneSurface adds a node displaying a single surface, not
+// including its subsurfaces.
+func CreateSceneSurface(parent SceneTree, surface Surface) SceneBuffer {
+	checkThread()
+
+	p := C.wlr_scene_surface_create(parent.p, surface.p)
+	return SceneBuffer{p: p.buffer}
+}
+
+// CreateSceneSubsurfaceTree adds a tree displaying surface and all of
+// its subsurfaces.
+func CreateSceneSubsurfaceTree(parent SceneTree, surface Surface) SceneTree {
+	checkThread()
+
+	p := C.wlr_scene_subsurface_tree_create(parent.p, surface.p)
+	return SceneTree{p: p}
+}
+
+// CreateSceneXDGSurface adds a tree displaying surface along with its
+// subsurfaces and popups.
+func CreateSceneXDGSurface(parent SceneTree, surface XDGSurface) SceneTree {
+	checkThread()
+
+	p := C.wlr_scene_xdg_surface_create(parent.p, surface.p)
+	return SceneTree{p: p}
+}
+
+type SceneLayerSurfaceV1 struct {
+	p *C.struct_wlr_scene_layer_surface_v1
+}
+
+// CreateSceneLayerSurfaceV1 adds a tree displaying surface along with
+// its subsurfaces and popups.
+func CreateSceneLayerSurfaceV1(parent SceneTree, surface LayerSurfaceV1) SceneLayerSurfaceV1 {
+	checkThread()
+
+	p := C.wlr_scene_layer_surface_v1_create(parent.p, surface.p)
+	return SceneLayerSurfaceV1{p: p}
+}
+
+func (s SceneLayerSurfaceV1) Valid() bool {
+	return s.p != nil
+}
+
+func (s SceneLayerSurfaceV1) Tree() SceneTree {
+	return SceneTree{p: s.p.tree}
+}
+
+func (s SceneLayerSurfaceV1) LayerSurface() LayerSurfaceV1 {
+	return LayerSurfaceV1{p: s.p.layer_surface}
+}
+
+// Configure positions and configures the layer surface within
+// fullArea and returns usableArea with the surface's exclusive zone,
+// if any, removed.
+func (s SceneLayerSurfaceV1) Configure(fullArea, usableArea image.Rectangle) image.Rectangle {
+	usable := newBox(usableArea)
+	C.wlr_scene_layer_surface_v1_configure(s.p, newBox(fullArea), usable)
+	return boxFromC(usable)
+}
+
+type SceneOutput struct {
+	p *C.struct_wlr_scene_output
+}
+
+func CreateSceneOutput(scene Scene, output Output) SceneOutput {
+	checkThread()
+
+	p := C.wlr_scene_output_create(scene.p, output.p)
+	return SceneOutput{p: p}
+}
+
+func (o SceneOutput) Valid() bool {
+	return o.p != nil
+}
+
+func (o SceneOutput) Destroy() {
+	C.wlr_scene_output_destroy(o.p)
+}
+
+func (o SceneOutput) Output() Output {
+	return Output{p: o.p.output}
+}
+
+func (o SceneOutput) SetPosition(lx, ly int) {
+	C.wlr_scene_output_set_position(o.p, C.int(lx), C.int(ly))
+}
+
+// Commit renders and commits a new frame to the output if any part of
+// the scene visible on it has been damaged.
+func (o SceneOutput) Commit() error {
+	checkThread()
+
+	if !C.wlr_scene_output_commit(o.p, nil) {
+		return errors.New("can't commit scene output")
+	}
+
+	return nil
+}
+
+// SendFrameDone sends frame done events to all surfaces visible on
+// the output.
+func (o SceneOutput) SendFrameDone(when time.Time) {
+	checkThread()
+
+	ts := timespecToC(when)
+	C.wlr_scene_output_send_frame_done(o.p, &ts)
+}
+
+type SceneOutputLayout struct {
+	p *C.struct_wlr_scene_output_layout
+}
+
+// AddOutput keeps the position of so in sync with the position of lo
+// in the layout.
+func (l SceneOutputLayout) AddOutput(lo OutputLayoutOutput, so SceneOutput) {
+	C.wlr_scene_output_layout_add_output(l.p, lo.p, so.p)
+}
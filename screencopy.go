@@ -12,6 +12,8 @@ type ScreencopyManagerV1 struct {
 }
 
 func CreateScreencopyManagerV1(display Display) ScreencopyManagerV1 {
+	checkThread()
+
 	p := C.wlr_screencopy_manager_v1_create(display.p)
 	return ScreencopyManagerV1{p: p}
 }
@@ -36,12 +36,18 @@ const (
 )
 
 func CreateSeat(display Display, name string) Seat {
+	checkThread()
+
 	s := C.CString(name)
 	defer C.free(unsafe.Pointer(s))
 	p := C.wlr_seat_create(display.p, s)
 	return Seat{p: p}
 }
 
+func (s Seat) Valid() bool {
+	return s.p != nil
+}
+
 func (s Seat) Destroy() {
 	C.wlr_seat_destroy(s.p)
 }
@@ -61,6 +67,54 @@ func (s Seat) OnRequestSetCursor(cb func(client SeatClient, surface Surface, ser
 	})
 }
 
+// OnPointerGrabBegin is called when a pointer grab starts, such as
+// when a popup takes an explicit grab on the seat.
+func (s Seat) OnPointerGrabBegin(cb func(Seat)) Listener {
+	return newListener(&s.p.events.pointer_grab_begin, func(lis Listener, data unsafe.Pointer) {
+		cb(s)
+	})
+}
+
+// OnPointerGrabEnd is called when a pointer grab ends, such as
+// when a popup releases an explicit grab on the seat.
+func (s Seat) OnPointerGrabEnd(cb func(Seat)) Listener {
+	return newListener(&s.p.events.pointer_grab_end, func(lis Listener, data unsafe.Pointer) {
+		cb(s)
+	})
+}
+
+// OnKeyboardGrabBegin is called when a keyboard grab starts, such as
+// when a popup takes an explicit grab on the seat.
+func (s Seat) OnKeyboardGrabBegin(cb func(Seat)) Listener {
+	return newListener(&s.p.events.keyboard_grab_begin, func(lis Listener, data unsafe.Pointer) {
+		cb(s)
+	})
+}
+
+// OnKeyboardGrabEnd is called when a keyboard grab ends, such as
+// when a popup releases an explicit grab on the seat.
+func (s Seat) OnKeyboardGrabEnd(cb func(Seat)) Listener {
+	return newListener(&s.p.events.keyboard_grab_end, func(lis Listener, data unsafe.Pointer) {
+		cb(s)
+	})
+}
+
+// OnTouchGrabBegin is called when a touch grab starts, such as
+// when a popup takes an explicit grab on the seat.
+func (s Seat) OnTouchGrabBegin(cb func(Seat)) Listener {
+	return newListener(&s.p.events.touch_grab_begin, func(lis Listener, data unsafe.Pointer) {
+		cb(s)
+	})
+}
+
+// OnTouchGrabEnd is called when a touch grab ends, such as
+// when a popup releases an explicit grab on the seat.
+func (s Seat) OnTouchGrabEnd(cb func(Seat)) Listener {
+	return newListener(&s.p.events.touch_grab_end, func(lis Listener, data unsafe.Pointer) {
+		cb(s)
+	})
+}
+
 func (s Seat) Capabilities() SeatCapability {
 	return SeatCapability(s.p.capabilities)
 }
@@ -79,26 +133,38 @@ func (s Seat) GetKeyboard() Keyboard {
 }
 
 func (s Seat) PointerNotifyButton(time time.Time, button CursorButton, state ButtonState) {
+	checkThread()
+
 	C.wlr_seat_pointer_notify_button(s.p, C.uint32_t(time.UnixMilli()), C.uint32_t(button), uint32(state))
 }
 
 func (s Seat) PointerNotifyAxis(time time.Time, orientation AxisOrientation, delta float64, deltaDiscrete int32, source AxisSource) {
+	checkThread()
+
 	C.wlr_seat_pointer_notify_axis(s.p, C.uint32_t(time.UnixMilli()), C.enum_wlr_axis_orientation(orientation), C.double(delta), C.int32_t(deltaDiscrete), C.enum_wlr_axis_source(source))
 }
 
 func (s Seat) PointerNotifyEnter(surface Surface, sx float64, sy float64) {
+	checkThread()
+
 	C.wlr_seat_pointer_notify_enter(s.p, surface.p, C.double(sx), C.double(sy))
 }
 
 func (s Seat) PointerNotifyMotion(time time.Time, sx float64, sy float64) {
+	checkThread()
+
 	C.wlr_seat_pointer_notify_motion(s.p, C.uint32_t(time.UnixMilli()), C.double(sx), C.double(sy))
 }
 
 func (s Seat) PointerNotifyFrame() {
+	checkThread()
+
 	C.wlr_seat_pointer_notify_frame(s.p)
 }
 
 func (s Seat) KeyboardNotifyEnter(surface Surface, keycodes []uint32, modifiers KeyboardModifiers) {
+	checkThread()
+
 	var kc *C.uint32_t
 	if len(keycodes) > 0 {
 		kc = (*C.uint32_t)(&keycodes[0])
@@ -108,14 +174,86 @@ func (s Seat) KeyboardNotifyEnter(surface Surface, keycodes []uint32, modifiers
 }
 
 func (s Seat) KeyboardNotifyModifiers(modifiers KeyboardModifiers) {
+	checkThread()
+
 	C.wlr_seat_keyboard_notify_modifiers(s.p, modifiers.p)
 }
 
 func (s Seat) KeyboardNotifyKey(time time.Time, keyCode uint32, state KeyState) {
+	checkThread()
+
 	C.wlr_seat_keyboard_notify_key(s.p, C.uint32_t(time.UnixMilli()), C.uint32_t(keyCode), C.uint32_t(state))
 }
 
+// TouchNotifyDown notifies the seat of a new touch point on surface at
+// the given surface-local coordinates. Surface will receive all
+// further events for the point. It returns the serial of the event
+// sent to the client, or zero if none was sent.
+func (s Seat) TouchNotifyDown(surface Surface, time time.Time, touchID int32, sx, sy float64) uint32 {
+	checkThread()
+
+	return uint32(C.wlr_seat_touch_notify_down(s.p, surface.p, C.uint32_t(time.UnixMilli()), C.int32_t(touchID), C.double(sx), C.double(sy)))
+}
+
+func (s Seat) TouchNotifyUp(time time.Time, touchID int32) {
+	checkThread()
+
+	C.wlr_seat_touch_notify_up(s.p, C.uint32_t(time.UnixMilli()), C.int32_t(touchID))
+}
+
+// TouchNotifyMotion notifies the seat that a touch point has moved.
+// The coordinates are relative to the surface that the point went down
+// on.
+func (s Seat) TouchNotifyMotion(time time.Time, touchID int32, sx, sy float64) {
+	checkThread()
+
+	C.wlr_seat_touch_notify_motion(s.p, C.uint32_t(time.UnixMilli()), C.int32_t(touchID), C.double(sx), C.double(sy))
+}
+
+// TouchNotifyCancel cancels all of the touch points of the client that
+// owns surface.
+func (s Seat) TouchNotifyCancel(surface Surface) {
+	checkThread()
+
+	C.wlr_seat_touch_notify_cancel(s.p, surface.p)
+}
+
+func (s Seat) TouchNotifyFrame() {
+	checkThread()
+
+	C.wlr_seat_touch_notify_frame(s.p)
+}
+
+// TouchPointFocus moves the focus of an existing touch point to
+// surface, such as during a drag-and-drop operation.
+func (s Seat) TouchPointFocus(surface Surface, time time.Time, touchID int32, sx, sy float64) {
+	checkThread()
+
+	C.wlr_seat_touch_point_focus(s.p, surface.p, C.uint32_t(time.UnixMilli()), C.int32_t(touchID), C.double(sx), C.double(sy))
+}
+
+func (s Seat) TouchPointClearFocus(time time.Time, touchID int32) {
+	checkThread()
+
+	C.wlr_seat_touch_point_clear_focus(s.p, C.uint32_t(time.UnixMilli()), C.int32_t(touchID))
+}
+
+// TouchGetPoint returns the touch point with the given ID. It is
+// invalid if there is no such point.
+func (s Seat) TouchGetPoint(touchID int32) TouchPoint {
+	p := C.wlr_seat_touch_get_point(s.p, C.int32_t(touchID))
+	return TouchPoint{p: p}
+}
+
+// TouchNumPoints returns the number of touch points that are currently
+// down.
+func (s Seat) TouchNumPoints() int {
+	return int(C.wlr_seat_touch_num_points(s.p))
+}
+
 func (s Seat) PointerNotifyClearFocus() {
+	checkThread()
+
 	C.wlr_seat_pointer_notify_clear_focus(s.p)
 }
 
@@ -143,3 +281,37 @@ func (s SeatPointerState) FocusedSurface() Surface {
 func (s SeatPointerState) FocusedClient() SeatClient {
 	return SeatClient{p: s.s.focused_client}
 }
+
+// TouchPoint is a single point of contact of a touch device.
+type TouchPoint struct {
+	p *C.struct_wlr_touch_point
+}
+
+func (p TouchPoint) Valid() bool {
+	return p.p != nil
+}
+
+func (p TouchPoint) TouchID() int32 {
+	return int32(p.p.touch_id)
+}
+
+// Surface returns the surface that the point went down on.
+func (p TouchPoint) Surface() Surface {
+	return Surface{p: p.p.surface}
+}
+
+func (p TouchPoint) Client() SeatClient {
+	return SeatClient{p: p.p.client}
+}
+
+// Focus returns the surface that currently has the point's focus,
+// which may differ from Surface during a drag.
+func (p TouchPoint) Focus() Surface {
+	return Surface{p: p.p.focus_surface}
+}
+
+// Position returns the most recent position of the point relative to
+// Surface.
+func (p TouchPoint) Position() (sx, sy float64) {
+	return float64(p.p.sx), float64(p.p.sy)
+}
@@ -0,0 +1,575 @@
+package wlr
+
+/*
+#include <stdlib.h>
+#include <wlr/types/wlr_seat.h>
+
+extern void _pointer_grab_enter(struct wlr_seat_pointer_grab *grab, struct wlr_surface *surface, double sx, double sy);
+extern void _pointer_grab_clear_focus(struct wlr_seat_pointer_grab *grab);
+extern void _pointer_grab_motion(struct wlr_seat_pointer_grab *grab, uint32_t time_msec, double sx, double sy);
+extern uint32_t _pointer_grab_button(struct wlr_seat_pointer_grab *grab, uint32_t time_msec, uint32_t button, uint32_t state);
+extern void _pointer_grab_axis(struct wlr_seat_pointer_grab *grab, uint32_t time_msec, uint32_t orientation, double value, int32_t value_discrete, uint32_t source);
+extern void _pointer_grab_frame(struct wlr_seat_pointer_grab *grab);
+extern void _pointer_grab_cancel(struct wlr_seat_pointer_grab *grab);
+
+static uint32_t _pointer_grab_button_cb(struct wlr_seat_pointer_grab *grab, uint32_t time_msec, uint32_t button, enum wlr_button_state state) {
+	return _pointer_grab_button(grab, time_msec, button, state);
+}
+
+static void _pointer_grab_axis_cb(struct wlr_seat_pointer_grab *grab, uint32_t time_msec, enum wlr_axis_orientation orientation, double value, int32_t value_discrete, enum wlr_axis_source source) {
+	_pointer_grab_axis(grab, time_msec, orientation, value, value_discrete, source);
+}
+
+static const struct wlr_pointer_grab_interface _pointer_grab_impl = {
+	.enter = _pointer_grab_enter,
+	.clear_focus = _pointer_grab_clear_focus,
+	.motion = _pointer_grab_motion,
+	.button = _pointer_grab_button_cb,
+	.axis = _pointer_grab_axis_cb,
+	.frame = _pointer_grab_frame,
+	.cancel = _pointer_grab_cancel,
+};
+
+static inline struct wlr_seat_pointer_grab *_new_pointer_grab(uintptr_t handle) {
+	struct wlr_seat_pointer_grab *grab = calloc(1, sizeof(*grab));
+	grab->interface = &_pointer_grab_impl;
+	grab->data = (void *)handle;
+	return grab;
+}
+
+extern void _keyboard_grab_enter(struct wlr_seat_keyboard_grab *grab, struct wlr_surface *surface, uint32_t *keycodes, size_t num_keycodes, struct wlr_keyboard_modifiers *modifiers);
+extern void _keyboard_grab_clear_focus(struct wlr_seat_keyboard_grab *grab);
+extern void _keyboard_grab_key(struct wlr_seat_keyboard_grab *grab, uint32_t time_msec, uint32_t key, uint32_t state);
+extern void _keyboard_grab_modifiers(struct wlr_seat_keyboard_grab *grab, struct wlr_keyboard_modifiers *modifiers);
+extern void _keyboard_grab_cancel(struct wlr_seat_keyboard_grab *grab);
+
+static void _keyboard_grab_enter_cb(struct wlr_seat_keyboard_grab *grab, struct wlr_surface *surface, const uint32_t keycodes[], size_t num_keycodes, const struct wlr_keyboard_modifiers *modifiers) {
+	_keyboard_grab_enter(grab, surface, (uint32_t *)keycodes, num_keycodes, (struct wlr_keyboard_modifiers *)modifiers);
+}
+
+static void _keyboard_grab_modifiers_cb(struct wlr_seat_keyboard_grab *grab, const struct wlr_keyboard_modifiers *modifiers) {
+	_keyboard_grab_modifiers(grab, (struct wlr_keyboard_modifiers *)modifiers);
+}
+
+static const struct wlr_keyboard_grab_interface _keyboard_grab_impl = {
+	.enter = _keyboard_grab_enter_cb,
+	.clear_focus = _keyboard_grab_clear_focus,
+	.key = _keyboard_grab_key,
+	.modifiers = _keyboard_grab_modifiers_cb,
+	.cancel = _keyboard_grab_cancel,
+};
+
+static inline struct wlr_seat_keyboard_grab *_new_keyboard_grab(uintptr_t handle) {
+	struct wlr_seat_keyboard_grab *grab = calloc(1, sizeof(*grab));
+	grab->interface = &_keyboard_grab_impl;
+	grab->data = (void *)handle;
+	return grab;
+}
+
+extern uint32_t _touch_grab_down(struct wlr_seat_touch_grab *grab, uint32_t time_msec, struct wlr_touch_point *point);
+extern void _touch_grab_up(struct wlr_seat_touch_grab *grab, uint32_t time_msec, struct wlr_touch_point *point);
+extern void _touch_grab_motion(struct wlr_seat_touch_grab *grab, uint32_t time_msec, struct wlr_touch_point *point);
+extern void _touch_grab_enter(struct wlr_seat_touch_grab *grab, uint32_t time_msec, struct wlr_touch_point *point);
+extern void _touch_grab_frame(struct wlr_seat_touch_grab *grab);
+extern void _touch_grab_cancel(struct wlr_seat_touch_grab *grab);
+extern void _touch_grab_wl_cancel(struct wlr_seat_touch_grab *grab, struct wlr_surface *surface);
+
+static const struct wlr_touch_grab_interface _touch_grab_impl = {
+	.down = _touch_grab_down,
+	.up = _touch_grab_up,
+	.motion = _touch_grab_motion,
+	.enter = _touch_grab_enter,
+	.frame = _touch_grab_frame,
+	.cancel = _touch_grab_cancel,
+	.wl_cancel = _touch_grab_wl_cancel,
+};
+
+static inline struct wlr_seat_touch_grab *_new_touch_grab(uintptr_t handle) {
+	struct wlr_seat_touch_grab *grab = calloc(1, sizeof(*grab));
+	grab->interface = &_touch_grab_impl;
+	grab->data = (void *)handle;
+	return grab;
+}
+
+static inline uintptr_t _grab_handle(void *data) {
+	return (uintptr_t)data;
+}
+*/
+import "C"
+
+import (
+	"runtime/cgo"
+	"time"
+	"unsafe"
+)
+
+// PointerGrab intercepts the pointer events of a seat while it is
+// active. Instead of being sent to the focused client, the events
+// passed to the seat's PointerNotify methods are passed to the grab,
+// which can handle them itself or forward them with the seat's
+// PointerSend methods. Embed DefaultPointerGrab to forward the events
+// that a grab does not need to handle.
+type PointerGrab interface {
+	Enter(seat Seat, surface Surface, sx, sy float64)
+	ClearFocus(seat Seat)
+	Motion(seat Seat, time time.Time, sx, sy float64)
+	// Button returns the serial of the event sent to the client, if
+	// any, or zero.
+	Button(seat Seat, time time.Time, button CursorButton, state ButtonState) uint32
+	Axis(seat Seat, time time.Time, orientation AxisOrientation, delta float64, deltaDiscrete int32, source AxisSource)
+	Frame(seat Seat)
+
+	// Cancel is called when the grab ends, either because of a call
+	// to PointerEndGrab or because it was replaced by another grab.
+	Cancel(seat Seat)
+}
+
+// KeyboardGrab is like PointerGrab but for keyboard events. Embed
+// DefaultKeyboardGrab to forward the events that a grab does not need
+// to handle.
+type KeyboardGrab interface {
+	Enter(seat Seat, surface Surface, keycodes []uint32, modifiers KeyboardModifiers)
+	ClearFocus(seat Seat)
+	Key(seat Seat, time time.Time, keyCode uint32, state KeyState)
+	Modifiers(seat Seat, modifiers KeyboardModifiers)
+	Cancel(seat Seat)
+}
+
+// TouchGrab is like PointerGrab but for touch events. Embed
+// DefaultTouchGrab to forward the events that a grab does not need to
+// handle.
+type TouchGrab interface {
+	Down(seat Seat, time time.Time, point TouchPoint) uint32
+	Up(seat Seat, time time.Time, point TouchPoint)
+	Motion(seat Seat, time time.Time, point TouchPoint)
+	Enter(seat Seat, time time.Time, point TouchPoint)
+	Frame(seat Seat)
+
+	// ClientCancel is called when the touch points of the client that
+	// owns surface are cancelled by TouchNotifyCancel.
+	ClientCancel(seat Seat, surface Surface)
+
+	// Cancel is called when the grab ends.
+	Cancel(seat Seat)
+}
+
+// DefaultPointerGrab forwards all events to the focused client, as if
+// there were no grab.
+type DefaultPointerGrab struct{}
+
+func (DefaultPointerGrab) Enter(seat Seat, surface Surface, sx, sy float64) {
+	seat.PointerEnter(surface, sx, sy)
+}
+
+func (DefaultPointerGrab) ClearFocus(seat Seat) {
+	seat.PointerClearFocus()
+}
+
+func (DefaultPointerGrab) Motion(seat Seat, time time.Time, sx, sy float64) {
+	seat.PointerSendMotion(time, sx, sy)
+}
+
+func (DefaultPointerGrab) Button(seat Seat, time time.Time, button CursorButton, state ButtonState) uint32 {
+	return seat.PointerSendButton(time, button, state)
+}
+
+func (DefaultPointerGrab) Axis(seat Seat, time time.Time, orientation AxisOrientation, delta float64, deltaDiscrete int32, source AxisSource) {
+	seat.PointerSendAxis(time, orientation, delta, deltaDiscrete, source)
+}
+
+func (DefaultPointerGrab) Frame(seat Seat) {
+	seat.PointerSendFrame()
+}
+
+func (DefaultPointerGrab) Cancel(seat Seat) {}
+
+// DefaultKeyboardGrab forwards all events to the focused client, as if
+// there were no grab.
+type DefaultKeyboardGrab struct{}
+
+func (DefaultKeyboardGrab) Enter(seat Seat, surface Surface, keycodes []uint32, modifiers KeyboardModifiers) {
+	seat.KeyboardEnter(surface, keycodes, modifiers)
+}
+
+func (DefaultKeyboardGrab) ClearFocus(seat Seat) {
+	seat.KeyboardClearFocus()
+}
+
+func (DefaultKeyboardGrab) Key(seat Seat, time time.Time, keyCode uint32, state KeyState) {
+	seat.KeyboardSendKey(time, keyCode, state)
+}
+
+func (DefaultKeyboardGrab) Modifiers(seat Seat, modifiers KeyboardModifiers) {
+	seat.KeyboardSendModifiers(modifiers)
+}
+
+func (DefaultKeyboardGrab) Cancel(seat Seat) {}
+
+// DefaultTouchGrab forwards all events to the client that owns each
+// touch point, as if there were no grab.
+type DefaultTouchGrab struct{}
+
+func (DefaultTouchGrab) Down(seat Seat, time time.Time, point TouchPoint) uint32 {
+	sx, sy := point.Position()
+	return seat.TouchSendDown(point.Surface(), time, point.TouchID(), sx, sy)
+}
+
+func (DefaultTouchGrab) Up(seat Seat, time time.Time, point TouchPoint) {
+	seat.TouchSendUp(time, point.TouchID())
+}
+
+func (DefaultTouchGrab) Motion(seat Seat, time time.Time, point TouchPoint) {
+	if focus := point.Focus(); focus.Valid() && focus != point.Surface() {
+		return
+	}
+
+	sx, sy := point.Position()
+	seat.TouchSendMotion(time, point.TouchID(), sx, sy)
+}
+
+func (DefaultTouchGrab) Enter(seat Seat, time time.Time, point TouchPoint) {}
+
+func (DefaultTouchGrab) Frame(seat Seat) {
+	seat.TouchSendFrame()
+}
+
+func (DefaultTouchGrab) ClientCancel(seat Seat, surface Surface) {
+	seat.TouchSendCancel(surface)
+}
+
+func (DefaultTouchGrab) Cancel(seat Seat) {}
+
+// seatGrab is the value of the handle of a grab started from Go.
+// wlr_seat_destroy does not end the seat's grabs, so the grab ends
+// itself from a destroy listener to make sure that it is cancelled
+// and freed.
+type seatGrab struct {
+	impl    any
+	handle  cgo.Handle
+	destroy Listener
+}
+
+func newSeatGrab(s Seat, impl any, end func()) cgo.Handle {
+	g := &seatGrab{impl: impl}
+	g.handle = cgo.NewHandle(g)
+	g.destroy = s.OnDestroy(func(Seat) { end() })
+	return g.handle
+}
+
+func seatGrabFromC(data unsafe.Pointer) *seatGrab {
+	return cgo.Handle(C._grab_handle(data)).Value().(*seatGrab)
+}
+
+// end frees the resources of the grab after it has been cancelled.
+func (g *seatGrab) end() {
+	g.destroy.Destroy()
+	g.handle.Delete()
+}
+
+// PointerStartGrab starts grab, ending the seat's current pointer grab
+// if it has one. Grabs are cancelled automatically if the seat is
+// destroyed.
+func (s Seat) PointerStartGrab(grab PointerGrab) {
+	checkThread()
+
+	if s.PointerHasGrab() {
+		s.PointerEndGrab()
+	}
+
+	handle := newSeatGrab(s, grab, s.PointerEndGrab)
+	C.wlr_seat_pointer_start_grab(s.p, C._new_pointer_grab(C.uintptr_t(handle)))
+}
+
+// PointerEndGrab ends the seat's current pointer grab, if any, and
+// restores the default behavior of sending events to the focused
+// client.
+func (s Seat) PointerEndGrab() {
+	checkThread()
+
+	C.wlr_seat_pointer_end_grab(s.p)
+}
+
+func (s Seat) PointerHasGrab() bool {
+	checkThread()
+
+	return bool(C.wlr_seat_pointer_has_grab(s.p))
+}
+
+func (s Seat) KeyboardStartGrab(grab KeyboardGrab) {
+	checkThread()
+
+	if s.KeyboardHasGrab() {
+		s.KeyboardEndGrab()
+	}
+
+	handle := newSeatGrab(s, grab, s.KeyboardEndGrab)
+	C.wlr_seat_keyboard_start_grab(s.p, C._new_keyboard_grab(C.uintptr_t(handle)))
+}
+
+func (s Seat) KeyboardEndGrab() {
+	checkThread()
+
+	C.wlr_seat_keyboard_end_grab(s.p)
+}
+
+func (s Seat) KeyboardHasGrab() bool {
+	checkThread()
+
+	return bool(C.wlr_seat_keyboard_has_grab(s.p))
+}
+
+func (s Seat) TouchStartGrab(grab TouchGrab) {
+	checkThread()
+
+	if s.TouchHasGrab() {
+		s.TouchEndGrab()
+	}
+
+	handle := newSeatGrab(s, grab, s.TouchEndGrab)
+	C.wlr_seat_touch_start_grab(s.p, C._new_touch_grab(C.uintptr_t(handle)))
+}
+
+func (s Seat) TouchEndGrab() {
+	checkThread()
+
+	C.wlr_seat_touch_end_grab(s.p)
+}
+
+func (s Seat) TouchHasGrab() bool {
+	checkThread()
+
+	return bool(C.wlr_seat_touch_has_grab(s.p))
+}
+
+// The following methods send events directly to clients, bypassing
+// any active grab. They are intended for use by grab implementations.
+
+func (s Seat) PointerEnter(surface Surface, sx, sy float64) {
+	checkThread()
+
+	C.wlr_seat_pointer_enter(s.p, surface.p, C.double(sx), C.double(sy))
+}
+
+func (s Seat) PointerClearFocus() {
+	checkThread()
+
+	C.wlr_seat_pointer_clear_focus(s.p)
+}
+
+func (s Seat) PointerSendMotion(time time.Time, sx, sy float64) {
+	checkThread()
+
+	C.wlr_seat_pointer_send_motion(s.p, C.uint32_t(time.UnixMilli()), C.double(sx), C.double(sy))
+}
+
+func (s Seat) PointerSendButton(time time.Time, button CursorButton, state ButtonState) uint32 {
+	checkThread()
+
+	return uint32(C.wlr_seat_pointer_send_button(s.p, C.uint32_t(time.UnixMilli()), C.uint32_t(button), C.enum_wlr_button_state(state)))
+}
+
+func (s Seat) PointerSendAxis(time time.Time, orientation AxisOrientation, delta float64, deltaDiscrete int32, source AxisSource) {
+	checkThread()
+
+	C.wlr_seat_pointer_send_axis(s.p, C.uint32_t(time.UnixMilli()), C.enum_wlr_axis_orientation(orientation), C.double(delta), C.int32_t(deltaDiscrete), C.enum_wlr_axis_source(source))
+}
+
+func (s Seat) PointerSendFrame() {
+	checkThread()
+
+	C.wlr_seat_pointer_send_frame(s.p)
+}
+
+func (s Seat) KeyboardEnter(surface Surface, keycodes []uint32, modifiers KeyboardModifiers) {
+	checkThread()
+
+	var kc *C.uint32_t
+	if len(keycodes) > 0 {
+		kc = (*C.uint32_t)(&keycodes[0])
+	}
+
+	C.wlr_seat_keyboard_enter(s.p, surface.p, kc, C.size_t(len(keycodes)), modifiers.p)
+}
+
+func (s Seat) KeyboardClearFocus() {
+	checkThread()
+
+	C.wlr_seat_keyboard_clear_focus(s.p)
+}
+
+func (s Seat) KeyboardSendKey(time time.Time, keyCode uint32, state KeyState) {
+	checkThread()
+
+	C.wlr_seat_keyboard_send_key(s.p, C.uint32_t(time.UnixMilli()), C.uint32_t(keyCode), C.uint32_t(state))
+}
+
+func (s Seat) KeyboardSendModifiers(modifiers KeyboardModifiers) {
+	checkThread()
+
+	C.wlr_seat_keyboard_send_modifiers(s.p, modifiers.p)
+}
+
+func (s Seat) TouchSendDown(surface Surface, time time.Time, touchID int32, sx, sy float64) uint32 {
+	checkThread()
+
+	return uint32(C.wlr_seat_touch_send_down(s.p, surface.p, C.uint32_t(time.UnixMilli()), C.int32_t(touchID), C.double(sx), C.double(sy)))
+}
+
+func (s Seat) TouchSendUp(time time.Time, touchID int32) {
+	checkThread()
+
+	C.wlr_seat_touch_send_up(s.p, C.uint32_t(time.UnixMilli()), C.int32_t(touchID))
+}
+
+func (s Seat) TouchSendMotion(time time.Time, touchID int32, sx, sy float64) {
+	checkThread()
+
+	C.wlr_seat_touch_send_motion(s.p, C.uint32_t(time.UnixMilli()), C.int32_t(touchID), C.double(sx), C.double(sy))
+}
+
+func (s Seat) TouchSendCancel(surface Surface) {
+	checkThread()
+
+	C.wlr_seat_touch_send_cancel(s.p, surface.p)
+}
+
+func (s Seat) TouchSendFrame() {
+	checkThread()
+
+	C.wlr_seat_touch_send_frame(s.p)
+}
+
+func pointerGrabFromC(grab *C.struct_wlr_seat_pointer_grab) (PointerGrab, Seat) {
+	return seatGrabFromC(grab.data).impl.(PointerGrab), Seat{p: grab.seat}
+}
+
+//export _pointer_grab_enter
+func _pointer_grab_enter(grab *C.struct_wlr_seat_pointer_grab, surface *C.struct_wlr_surface, sx, sy C.double) {
+	g, seat := pointerGrabFromC(grab)
+	g.Enter(seat, Surface{p: surface}, float64(sx), float64(sy))
+}
+
+//export _pointer_grab_clear_focus
+func _pointer_grab_clear_focus(grab *C.struct_wlr_seat_pointer_grab) {
+	g, seat := pointerGrabFromC(grab)
+	g.ClearFocus(seat)
+}
+
+//export _pointer_grab_motion
+func _pointer_grab_motion(grab *C.struct_wlr_seat_pointer_grab, t C.uint32_t, sx, sy C.double) {
+	g, seat := pointerGrabFromC(grab)
+	g.Motion(seat, time.UnixMilli(int64(t)), float64(sx), float64(sy))
+}
+
+//export _pointer_grab_button
+func _pointer_grab_button(grab *C.struct_wlr_seat_pointer_grab, t C.uint32_t, button C.uint32_t, state C.uint32_t) C.uint32_t {
+	g, seat := pointerGrabFromC(grab)
+	return C.uint32_t(g.Button(seat, time.UnixMilli(int64(t)), CursorButton(button), ButtonState(state)))
+}
+
+//export _pointer_grab_axis
+func _pointer_grab_axis(grab *C.struct_wlr_seat_pointer_grab, t C.uint32_t, orientation C.uint32_t, value C.double, valueDiscrete C.int32_t, source C.uint32_t) {
+	g, seat := pointerGrabFromC(grab)
+	g.Axis(seat, time.UnixMilli(int64(t)), AxisOrientation(orientation), float64(value), int32(valueDiscrete), AxisSource(source))
+}
+
+//export _pointer_grab_frame
+func _pointer_grab_frame(grab *C.struct_wlr_seat_pointer_grab) {
+	g, seat := pointerGrabFromC(grab)
+	g.Frame(seat)
+}
+
+//export _pointer_grab_cancel
+func _pointer_grab_cancel(grab *C.struct_wlr_seat_pointer_grab) {
+	g := seatGrabFromC(grab.data)
+	defer C.free(unsafe.Pointer(grab))
+	defer g.end()
+
+	g.impl.(PointerGrab).Cancel(Seat{p: grab.seat})
+}
+
+func keyboardGrabFromC(grab *C.struct_wlr_seat_keyboard_grab) (KeyboardGrab, Seat) {
+	return seatGrabFromC(grab.data).impl.(KeyboardGrab), Seat{p: grab.seat}
+}
+
+//export _keyboard_grab_enter
+func _keyboard_grab_enter(grab *C.struct_wlr_seat_keyboard_grab, surface *C.struct_wlr_surface, keycodes *C.uint32_t, numKeycodes C.size_t, modifiers *C.struct_wlr_keyboard_modifiers) {
+	g, seat := keyboardGrabFromC(grab)
+	g.Enter(seat, Surface{p: surface}, unsafe.Slice((*uint32)(keycodes), numKeycodes), KeyboardModifiers{p: modifiers})
+}
+
+//export _keyboard_grab_clear_focus
+func _keyboard_grab_clear_focus(grab *C.struct_wlr_seat_keyboard_grab) {
+	g, seat := keyboardGrabFromC(grab)
+	g.ClearFocus(seat)
+}
+
+//export _keyboard_grab_key
+func _keyboard_grab_key(grab *C.struct_wlr_seat_keyboard_grab, t C.uint32_t, key C.uint32_t, state C.uint32_t) {
+	g, seat := keyboardGrabFromC(grab)
+	g.Key(seat, time.UnixMilli(int64(t)), uint32(key), KeyState(state))
+}
+
+//export _keyboard_grab_modifiers
+func _keyboard_grab_modifiers(grab *C.struct_wlr_seat_keyboard_grab, modifiers *C.struct_wlr_keyboard_modifiers) {
+	g, seat := keyboardGrabFromC(grab)
+	g.Modifiers(seat, KeyboardModifiers{p: modifiers})
+}
+
+//export _keyboard_grab_cancel
+func _keyboard_grab_cancel(grab *C.struct_wlr_seat_keyboard_grab) {
+	g := seatGrabFromC(grab.data)
+	defer C.free(unsafe.Pointer(grab))
+	defer g.end()
+
+	g.impl.(KeyboardGrab).Cancel(Seat{p: grab.seat})
+}
+
+func touchGrabFromC(grab *C.struct_wlr_seat_touch_grab) (TouchGrab, Seat) {
+	return seatGrabFromC(grab.data).impl.(TouchGrab), Seat{p: grab.seat}
+}
+
+//export _touch_grab_down
+func _touch_grab_down(grab *C.struct_wlr_seat_touch_grab, t C.uint32_t, point *C.struct_wlr_touch_point) C.uint32_t {
+	g, seat := touchGrabFromC(grab)
+	return C.uint32_t(g.Down(seat, time.UnixMilli(int64(t)), TouchPoint{p: point}))
+}
+
+//export _touch_grab_up
+func _touch_grab_up(grab *C.struct_wlr_seat_touch_grab, t C.uint32_t, point *C.struct_wlr_touch_point) {
+	g, seat := touchGrabFromC(grab)
+	g.Up(seat, time.UnixMilli(int64(t)), TouchPoint{p: point})
+}
+
+//export _touch_grab_motion
+func _touch_grab_motion(grab *C.struct_wlr_seat_touch_grab, t C.uint32_t, point *C.struct_wlr_touch_point) {
+	g, seat := touchGrabFromC(grab)
+	g.Motion(seat, time.UnixMilli(int64(t)), TouchPoint{p: point})
+}
+
+//export _touch_grab_enter
+func _touch_grab_enter(grab *C.struct_wlr_seat_touch_grab, t C.uint32_t, point *C.struct_wlr_touch_point) {
+	g, seat := touchGrabFromC(grab)
+	g.Enter(seat, time.UnixMilli(int64(t)), TouchPoint{p: point})
+}
+
+//export _touch_grab_frame
+func _touch_grab_frame(grab *C.struct_wlr_seat_touch_grab) {
+	g, seat := touchGrabFromC(grab)
+	g.Frame(seat)
+}
+
+//export _touch_grab_wl_cancel
+func _touch_grab_wl_cancel(grab *C.struct_wlr_seat_touch_grab, surface *C.struct_wlr_surface) {
+	g, seat := touchGrabFromC(grab)
+	g.ClientCancel(seat, Surface{p: surface})
+}
+
+//export _touch_grab_cancel
+func _touch_grab_cancel(grab *C.struct_wlr_seat_touch_grab) {
+	g := seatGrabFromC(grab.data)
+	defer C.free(unsafe.Pointer(grab))
+	defer g.end()
+
+	g.impl.(TouchGrab).Cancel(Seat{p: grab.seat})
+}
@@ -24,6 +24,8 @@ type ServerDecoration struct {
 }
 
 func CreateServerDecorationManager(display Display) ServerDecorationManager {
+	checkThread()
+
 	p := C.wlr_server_decoration_manager_create(display.p)
 	return ServerDecorationManager{p: p}
 }
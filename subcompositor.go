@@ -5,11 +5,112 @@ package wlr
 */
 import "C"
 
+import (
+	"iter"
+	"unsafe"
+)
+
 type Subcompositor struct {
 	p *C.struct_wlr_subcompositor
 }
 
 func CreateSubcompositor(display Display) Subcompositor {
+	checkThread()
+
 	p := C.wlr_subcompositor_create(display.p)
 	return Subcompositor{p: p}
 }
+
+func (s Subcompositor) OnDestroy(cb func(Subcompositor)) Listener {
+	return newListener(&s.p.events.destroy, func(lis Listener, data unsafe.Pointer) {
+		cb(s)
+	})
+}
+
+// Subsurface is a surface that is positioned relative to and drawn
+// along with a parent surface. New subsurfaces are announced by
+// Surface.OnNewSubsurface on their parent.
+type Subsurface struct {
+	p *C.struct_wlr_subsurface
+}
+
+// SubsurfaceFromSurface returns the subsurface role object of s. It
+// is invalid if s is not a subsurface.
+func SubsurfaceFromSurface(s Surface) Subsurface {
+	p := C.wlr_subsurface_try_from_wlr_surface(s.p)
+	return Subsurface{p: p}
+}
+
+func (s Subsurface) Valid() bool {
+	return s.p != nil
+}
+
+func (s Subsurface) OnDestroy(cb func(Subsurface)) Listener {
+	return newListener(&s.p.events.destroy, func(lis Listener, data unsafe.Pointer) {
+		cb(s)
+	})
+}
+
+// OnMap is a convenience method that listens for the subsurface's
+// surface being mapped.
+func (s Subsurface) OnMap(cb func(Subsurface)) Listener {
+	return s.Surface().OnMap(func(Surface) { cb(s) })
+}
+
+// OnUnmap is a convenience method that listens for the subsurface's
+// surface being unmapped.
+func (s Subsurface) OnUnmap(cb func(Subsurface)) Listener {
+	return s.Surface().OnUnmap(func(Surface) { cb(s) })
+}
+
+func (s Subsurface) Surface() Surface {
+	return Surface{p: s.p.surface}
+}
+
+func (s Subsurface) Parent() Surface {
+	return Surface{p: s.p.parent}
+}
+
+// Position returns the current position of the subsurface relative to
+// its parent.
+func (s Subsurface) Position() (x, y int) {
+	return int(s.p.current.x), int(s.p.current.y)
+}
+
+// PendingPosition returns the position that will be applied on the
+// next commit of the parent surface.
+func (s Subsurface) PendingPosition() (x, y int) {
+	return int(s.p.pending.x), int(s.p.pending.y)
+}
+
+// Synchronized returns true if the subsurface is in synchronized
+// mode, in which case its state is only applied when its parent
+// commits.
+func (s Subsurface) Synchronized() bool {
+	return bool(s.p.synchronized)
+}
+
+// SubsurfacesBelow yields the subsurfaces that are stacked below s,
+// from bottom to top, as ordered by the client's place_above and
+// place_below requests.
+func (s Surface) SubsurfacesBelow() iter.Seq[Subsurface] {
+	return subsurfaceSeq(&s.p.current.subsurfaces_below)
+}
+
+// SubsurfacesAbove yields the subsurfaces that are stacked above s,
+// from bottom to top.
+func (s Surface) SubsurfacesAbove() iter.Seq[Subsurface] {
+	return subsurfaceSeq(&s.p.current.subsurfaces_above)
+}
+
+func subsurfaceSeq(head *C.struct_wl_list) iter.Seq[Subsurface] {
+	offset := int(unsafe.Offsetof(C.struct_wlr_subsurface{}.current) + unsafe.Offsetof(C.struct_wlr_subsurface_parent_state{}.link))
+	return func(yield func(Subsurface) bool) {
+		seq := listSeq[C.struct_wlr_subsurface](head, offset)
+		for sub := range seq {
+			if !yield(Subsurface{p: sub}) {
+				return
+			}
+		}
+	}
+}
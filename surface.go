@@ -56,6 +56,14 @@ type Surface struct {
 	p *C.struct_wlr_surface
 }
 
+// SurfaceFromResource returns the surface of a wl_surface resource,
+// such as one received as an argument of a request to a Go-implemented
+// resource.
+func SurfaceFromResource(r Resource) Surface {
+	p := C.wlr_surface_from_resource(r.p)
+	return Surface{p: p}
+}
+
 func (s Surface) Valid() bool {
 	return s.p != nil
 }
@@ -72,6 +80,20 @@ func (s Surface) OnUnmap(cb func(Surface)) Listener {
 	})
 }
 
+// OnCommit is called when the client commits the surface, after its
+// pending state has been applied.
+func (s Surface) OnCommit(cb func(Surface)) Listener {
+	return newListener(&s.p.events.commit, func(lis Listener, data unsafe.Pointer) {
+		cb(s)
+	})
+}
+
+func (s Surface) OnNewSubsurface(cb func(Subsurface)) Listener {
+	return newListener(&s.p.events.new_subsurface, func(lis Listener, data unsafe.Pointer) {
+		cb(Subsurface{p: (*C.struct_wlr_subsurface)(data)})
+	})
+}
+
 func (s Surface) OnDestroy(cb func(Surface)) Listener {
 	return newListener(&s.p.events.destroy, func(lis Listener, data unsafe.Pointer) {
 		cb(s)
@@ -93,10 +115,70 @@ func (s Surface) GetTexture() Texture {
 	return Texture{p: p}
 }
 
+// GetEffectiveDamage returns the damage of the surface's last commit
+// in surface-local coordinates, accounting for changes to its size,
+// scale, and transform. The returned Region must be destroyed by the
+// caller.
+func (s Surface) GetEffectiveDamage() Region {
+	damage := NewRegion()
+	C.wlr_surface_get_effective_damage(s.p, damage.p)
+	return damage
+}
+
 func (s Surface) Current() SurfaceState {
 	return SurfaceState{v: s.p.current}
 }
 
+// Pending returns the state that the client has set but not yet
+// committed.
+func (s Surface) Pending() SurfaceState {
+	return SurfaceState{v: s.p.pending}
+}
+
+// Buffer returns the buffer that is currently attached to the
+// surface. It is invalid if the surface has no buffer.
+func (s Surface) Buffer() Buffer {
+	if s.p.buffer == nil {
+		return Buffer{}
+	}
+
+	return Buffer{p: &s.p.buffer.base}
+}
+
+// BufferDamage returns the damage of the last commit in buffer-local
+// coordinates. The returned Region belongs to the surface and must not
+// be modified or destroyed.
+func (s Surface) BufferDamage() Region {
+	return Region{p: &s.p.buffer_damage}
+}
+
+// OpaqueRegion returns the area of the surface, in surface-local
+// coordinates, that is known to be opaque. The returned Region belongs
+// to the surface and must not be modified or destroyed.
+func (s Surface) OpaqueRegion() Region {
+	return Region{p: &s.p.opaque_region}
+}
+
+// InputRegion returns the area of the surface, in surface-local
+// coordinates, that accepts input. The returned Region belongs to the
+// surface and must not be modified or destroyed.
+func (s Surface) InputRegion() Region {
+	return Region{p: &s.p.input_region}
+}
+
+// PointAcceptsInput returns true if the point, in surface-local
+// coordinates, is within both the surface and its input region.
+func (s Surface) PointAcceptsInput(sx, sy float64) bool {
+	return bool(C.wlr_surface_point_accepts_input(s.p, C.double(sx), C.double(sy)))
+}
+
+// GetRootSurface returns the top-most ancestor of a subsurface, or s
+// itself if it is not a subsurface.
+func (s Surface) GetRootSurface() Surface {
+	p := C.wlr_surface_get_root_surface(s.p)
+	return Surface{p: p}
+}
+
 func (s Surface) ForEachSurface(cb func(Surface, int, int)) {
 	handle := cgo.NewHandle(cb)
 	defer handle.Delete()
@@ -133,18 +215,28 @@ func _wlr_surface_for_each_cb(surface *C.struct_wlr_surface, sx C.int, sy C.int,
 }
 
 func (s Surface) SendEnter(output Output) {
+	checkThread()
+
 	C.wlr_surface_send_enter(s.p, output.p)
 }
 
 func (s Surface) SendLeave(output Output) {
+	checkThread()
+
 	C.wlr_surface_send_leave(s.p, output.p)
 }
 
 func (s Surface) SendFrameDone(when time.Time) {
-	ts := C._new_timespec(C.long(when.Unix()), C.long(when.Nanosecond()))
+	checkThread()
+
+	ts := timespecToC(when)
 	C.wlr_surface_send_frame_done(s.p, &ts)
 }
 
+func timespecToC(t time.Time) C.struct_timespec {
+	return C._new_timespec(C.long(t.Unix()), C.long(t.Nanosecond()))
+}
+
 func (s Surface) XwaylandSurface() XwaylandSurface {
 	p := C.wlr_xwayland_surface_try_from_wlr_surface(s.p)
 	return XwaylandSurface{p: p}
@@ -155,6 +247,11 @@ func (s Surface) XDGSurface() XDGSurface {
 	return XDGSurface{p: p}
 }
 
+// SurfaceState is a snapshot of a surface's double-buffered state.
+// It shares memory with the surface and is only valid until the
+// surface's state next changes. Buffers returned from it belong to
+// the surface, while regions returned from it are copies that must be
+// destroyed by the caller.
 type SurfaceState struct {
 	v C.struct_wlr_surface_state
 }
@@ -178,3 +275,62 @@ func (s SurfaceState) Height() int {
 func (s SurfaceState) Transform() OutputTransform {
 	return OutputTransform(s.v.transform)
 }
+
+// Scale returns the buffer scale of the surface.
+func (s SurfaceState) Scale() int {
+	return int(s.v.scale)
+}
+
+func (s SurfaceState) BufferWidth() int {
+	return int(s.v.buffer_width)
+}
+
+func (s SurfaceState) BufferHeight() int {
+	return int(s.v.buffer_height)
+}
+
+// Buffer returns the buffer attached in this state. It is invalid if
+// no buffer is attached.
+func (s SurfaceState) Buffer() Buffer {
+	return Buffer{p: s.v.buffer}
+}
+
+// SurfaceDamage returns a copy of the damage of the state in
+// surface-local coordinates. The returned Region must be destroyed by
+// the caller.
+func (s SurfaceState) SurfaceDamage() Region {
+	return Region{p: &s.v.surface_damage}.Copy()
+}
+
+// BufferDamage returns a copy of the damage of the state in
+// buffer-local coordinates. The returned Region must be destroyed by
+// the caller.
+func (s SurfaceState) BufferDamage() Region {
+	return Region{p: &s.v.buffer_damage}.Copy()
+}
+
+// Opaque returns a copy of the opaque region of the state. The
+// returned Region must be destroyed by the caller.
+func (s SurfaceState) Opaque() Region {
+	return Region{p: &s.v.opaque}.Copy()
+}
+
+// Input returns a copy of the input region of the state. The returned
+// Region must be destroyed by the caller.
+func (s SurfaceState) Input() Region {
+	return Region{p: &s.v.input}.Copy()
+}
+
+// ViewportSource returns the source rectangle of the surface's
+// viewport, in surface-local coordinates before scaling, if one is
+// set.
+func (s SurfaceState) ViewportSource() (x, y, width, height float64, ok bool) {
+	src := s.v.viewport.src
+	return float64(src.x), float64(src.y), float64(src.width), float64(src.height), bool(s.v.viewport.has_src)
+}
+
+// ViewportDestination returns the size that the surface's viewport
+// scales it to, if one is set.
+func (s SurfaceState) ViewportDestination() (width, height int, ok bool) {
+	return int(s.v.viewport.dst_width), int(s.v.viewport.dst_height), bool(s.v.viewport.has_dst)
+}
@@ -0,0 +1,327 @@
+package wlr
+
+/*
+#include <wlr/types/wlr_tablet_pad.h>
+#include <wlr/types/wlr_tablet_tool.h>
+*/
+import "C"
+
+import (
+	"time"
+	"unsafe"
+)
+
+type TabletToolType uint32
+
+const (
+	TabletToolTypePen      TabletToolType = C.WLR_TABLET_TOOL_TYPE_PEN
+	TabletToolTypeEraser   TabletToolType = C.WLR_TABLET_TOOL_TYPE_ERASER
+	TabletToolTypeBrush    TabletToolType = C.WLR_TABLET_TOOL_TYPE_BRUSH
+	TabletToolTypePencil   TabletToolType = C.WLR_TABLET_TOOL_TYPE_PENCIL
+	TabletToolTypeAirbrush TabletToolType = C.WLR_TABLET_TOOL_TYPE_AIRBRUSH
+	TabletToolTypeMouse    TabletToolType = C.WLR_TABLET_TOOL_TYPE_MOUSE
+	TabletToolTypeLens     TabletToolType = C.WLR_TABLET_TOOL_TYPE_LENS
+	TabletToolTypeTotem    TabletToolType = C.WLR_TABLET_TOOL_TYPE_TOTEM
+)
+
+// TabletToolAxes is a set of flags indicating which axes of a tablet
+// tool have changed in an axis event.
+type TabletToolAxes uint32
+
+const (
+	TabletToolAxisX        TabletToolAxes = C.WLR_TABLET_TOOL_AXIS_X
+	TabletToolAxisY        TabletToolAxes = C.WLR_TABLET_TOOL_AXIS_Y
+	TabletToolAxisDistance TabletToolAxes = C.WLR_TABLET_TOOL_AXIS_DISTANCE
+	TabletToolAxisPressure TabletToolAxes = C.WLR_TABLET_TOOL_AXIS_PRESSURE
+	TabletToolAxisTiltX    TabletToolAxes = C.WLR_TABLET_TOOL_AXIS_TILT_X
+	TabletToolAxisTiltY    TabletToolAxes = C.WLR_TABLET_TOOL_AXIS_TILT_Y
+	TabletToolAxisRotation TabletToolAxes = C.WLR_TABLET_TOOL_AXIS_ROTATION
+	TabletToolAxisSlider   TabletToolAxes = C.WLR_TABLET_TOOL_AXIS_SLIDER
+	TabletToolAxisWheel    TabletToolAxes = C.WLR_TABLET_TOOL_AXIS_WHEEL
+)
+
+type TabletToolProximityState uint32
+
+const (
+	TabletToolProximityOut TabletToolProximityState = C.WLR_TABLET_TOOL_PROXIMITY_OUT
+	TabletToolProximityIn  TabletToolProximityState = C.WLR_TABLET_TOOL_PROXIMITY_IN
+)
+
+type TabletToolTipState uint32
+
+const (
+	TabletToolTipUp   TabletToolTipState = C.WLR_TABLET_TOOL_TIP_UP
+	TabletToolTipDown TabletToolTipState = C.WLR_TABLET_TOOL_TIP_DOWN
+)
+
+type TabletPadRingSource uint32
+
+const (
+	TabletPadRingSourceUnknown TabletPadRingSource = C.WLR_TABLET_PAD_RING_SOURCE_UNKNOWN
+	TabletPadRingSourceFinger  TabletPadRingSource = C.WLR_TABLET_PAD_RING_SOURCE_FINGER
+)
+
+type TabletPadStripSource uint32
+
+const (
+	TabletPadStripSourceUnknown TabletPadStripSource = C.WLR_TABLET_PAD_STRIP_SOURCE_UNKNOWN
+	TabletPadStripSourceFinger  TabletPadStripSource = C.WLR_TABLET_PAD_STRIP_SOURCE_FINGER
+)
+
+// Tablet is a drawing tablet. The tools that are used with it, such
+// as pens and erasers, are represented by TabletTool and are reported
+// by its events.
+type Tablet struct {
+	p *C.struct_wlr_tablet
+}
+
+func (t Tablet) Valid() bool {
+	return t.p != nil
+}
+
+func (t Tablet) Base() InputDevice {
+	return InputDevice{p: &t.p.base}
+}
+
+// Size returns the physical size of the tablet in millimeters, or zero
+// if it is unknown.
+func (t Tablet) Size() (width, height float64) {
+	return float64(t.p.width_mm), float64(t.p.height_mm)
+}
+
+// Paths returns the udev paths of the device.
+func (t Tablet) Paths() []string {
+	return wlArrayStrings(&t.p.paths)
+}
+
+func (t Tablet) OnAxis(cb func(TabletToolAxisEvent)) Listener {
+	return newListener(&t.p.events.axis, func(lis Listener, data unsafe.Pointer) {
+		cb(tabletToolAxisEventFromC((*C.struct_wlr_tablet_tool_axis_event)(data)))
+	})
+}
+
+func (t Tablet) OnProximity(cb func(TabletToolProximityEvent)) Listener {
+	return newListener(&t.p.events.proximity, func(lis Listener, data unsafe.Pointer) {
+		cb(tabletToolProximityEventFromC((*C.struct_wlr_tablet_tool_proximity_event)(data)))
+	})
+}
+
+func (t Tablet) OnTip(cb func(TabletToolTipEvent)) Listener {
+	return newListener(&t.p.events.tip, func(lis Listener, data unsafe.Pointer) {
+		cb(tabletToolTipEventFromC((*C.struct_wlr_tablet_tool_tip_event)(data)))
+	})
+}
+
+func (t Tablet) OnButton(cb func(TabletToolButtonEvent)) Listener {
+	return newListener(&t.p.events.button, func(lis Listener, data unsafe.Pointer) {
+		cb(tabletToolButtonEventFromC((*C.struct_wlr_tablet_tool_button_event)(data)))
+	})
+}
+
+// TabletTool is a physical tool, such as a pen, that is used with a
+// Tablet. The same tool may be used with more than one tablet.
+type TabletTool struct {
+	p *C.struct_wlr_tablet_tool
+}
+
+func (t TabletTool) Valid() bool {
+	return t.p != nil
+}
+
+func (t TabletTool) OnDestroy(cb func(TabletTool)) Listener {
+	return newListener(&t.p.events.destroy, func(lis Listener, data unsafe.Pointer) {
+		cb(t)
+	})
+}
+
+func (t TabletTool) Type() TabletToolType {
+	return TabletToolType(t.p._type)
+}
+
+// HardwareSerial returns the serial number of the tool, or zero if it
+// is unknown.
+func (t TabletTool) HardwareSerial() uint64 {
+	return uint64(t.p.hardware_serial)
+}
+
+// HardwareWacom returns the Wacom-specific tool ID, or zero if it is
+// unknown.
+func (t TabletTool) HardwareWacom() uint64 {
+	return uint64(t.p.hardware_wacom)
+}
+
+func (t TabletTool) HasTilt() bool     { return bool(t.p.tilt) }
+func (t TabletTool) HasPressure() bool { return bool(t.p.pressure) }
+func (t TabletTool) HasDistance() bool { return bool(t.p.distance) }
+func (t TabletTool) HasRotation() bool { return bool(t.p.rotation) }
+func (t TabletTool) HasSlider() bool   { return bool(t.p.slider) }
+func (t TabletTool) HasWheel() bool    { return bool(t.p.wheel) }
+
+// TabletToolAxisEvent is emitted when one or more axes of a tablet
+// tool change. X and Y are normalized to the range [0, 1]. Only the
+// axes in UpdatedAxes have meaningful values.
+type TabletToolAxisEvent struct {
+	Tablet      Tablet
+	Tool        TabletTool
+	Time        time.Time
+	UpdatedAxes TabletToolAxes
+
+	X, Y               float64
+	DX, DY             float64
+	Pressure, Distance float64
+	TiltX, TiltY       float64
+	Rotation, Slider   float64
+	WheelDelta         float64
+}
+
+func tabletToolAxisEventFromC(event *C.struct_wlr_tablet_tool_axis_event) TabletToolAxisEvent {
+	return TabletToolAxisEvent{
+		Tablet:      Tablet{p: event.tablet},
+		Tool:        TabletTool{p: event.tool},
+		Time:        time.UnixMilli(int64(event.time_msec)),
+		UpdatedAxes: TabletToolAxes(event.updated_axes),
+		X:           float64(event.x),
+		Y:           float64(event.y),
+		DX:          float64(event.dx),
+		DY:          float64(event.dy),
+		Pressure:    float64(event.pressure),
+		Distance:    float64(event.distance),
+		TiltX:       float64(event.tilt_x),
+		TiltY:       float64(event.tilt_y),
+		Rotation:    float64(event.rotation),
+		Slider:      float64(event.slider),
+		WheelDelta:  float64(event.wheel_delta),
+	}
+}
+
+// TabletToolProximityEvent is emitted when a tool moves into or out of
+// the range in which the tablet can sense it.
+type TabletToolProximityEvent struct {
+	Tablet Tablet
+	Tool   TabletTool
+	Time   time.Time
+	X, Y   float64
+	State  TabletToolProximityState
+}
+
+func tabletToolProximityEventFromC(event *C.struct_wlr_tablet_tool_proximity_event) TabletToolProximityEvent {
+	return TabletToolProximityEvent{
+		Tablet: Tablet{p: event.tablet},
+		Tool:   TabletTool{p: event.tool},
+		Time:   time.UnixMilli(int64(event.time_msec)),
+		X:      float64(event.x),
+		Y:      float64(event.y),
+		State:  TabletToolProximityState(event.state),
+	}
+}
+
+// TabletToolTipEvent is emitted when the tip of a tool touches or
+// stops touching the tablet.
+type TabletToolTipEvent struct {
+	Tablet Tablet
+	Tool   TabletTool
+	Time   time.Time
+	X, Y   float64
+	State  TabletToolTipState
+}
+
+func tabletToolTipEventFromC(event *C.struct_wlr_tablet_tool_tip_event) TabletToolTipEvent {
+	return TabletToolTipEvent{
+		Tablet: Tablet{p: event.tablet},
+		Tool:   TabletTool{p: event.tool},
+		Time:   time.UnixMilli(int64(event.time_msec)),
+		X:      float64(event.x),
+		Y:      float64(event.y),
+		State:  TabletToolTipState(event.state),
+	}
+}
+
+// TabletToolButtonEvent is emitted when a button on a tool is pressed
+// or released.
+type TabletToolButtonEvent struct {
+	Tablet Tablet
+	Tool   TabletTool
+	Time   time.Time
+	Button uint32
+	State  ButtonState
+}
+
+func tabletToolButtonEventFromC(event *C.struct_wlr_tablet_tool_button_event) TabletToolButtonEvent {
+	return TabletToolButtonEvent{
+		Tablet: Tablet{p: event.tablet},
+		Tool:   TabletTool{p: event.tool},
+		Time:   time.UnixMilli(int64(event.time_msec)),
+		Button: uint32(event.button),
+		State:  ButtonState(event.state),
+	}
+}
+
+// TabletPad is the set of buttons, rings, and strips on a drawing
+// tablet.
+type TabletPad struct {
+	p *C.struct_wlr_tablet_pad
+}
+
+func (p TabletPad) Valid() bool {
+	return p.p != nil
+}
+
+func (p TabletPad) Base() InputDevice {
+	return InputDevice{p: &p.p.base}
+}
+
+func (p TabletPad) ButtonCount() int {
+	return int(p.p.button_count)
+}
+
+func (p TabletPad) RingCount() int {
+	return int(p.p.ring_count)
+}
+
+func (p TabletPad) StripCount() int {
+	return int(p.p.strip_count)
+}
+
+// Paths returns the udev paths of the device.
+func (p TabletPad) Paths() []string {
+	return wlArrayStrings(&p.p.paths)
+}
+
+func (p TabletPad) OnButton(cb func(pad TabletPad, time time.Time, button uint32, state ButtonState, mode, group uint32)) Listener {
+	return newListener(&p.p.events.button, func(lis Listener, data unsafe.Pointer) {
+		event := (*C.struct_wlr_tablet_pad_button_event)(data)
+		cb(p, time.UnixMilli(int64(event.time_msec)), uint32(event.button), ButtonState(event.state), uint32(event.mode), uint32(event.group))
+	})
+}
+
+// OnRing is called when a finger moves on a ring. Position is in
+// degrees clockwise from the top, or -1 if the finger was lifted.
+func (p TabletPad) OnRing(cb func(pad TabletPad, time time.Time, ring uint32, source TabletPadRingSource, position float64, mode uint32)) Listener {
+	return newListener(&p.p.events.ring, func(lis Listener, data unsafe.Pointer) {
+		event := (*C.struct_wlr_tablet_pad_ring_event)(data)
+		cb(p, time.UnixMilli(int64(event.time_msec)), uint32(event.ring), TabletPadRingSource(event.source), float64(event.position), uint32(event.mode))
+	})
+}
+
+// OnStrip is called when a finger moves on a strip. Position is
+// normalized to the range [0, 1], or -1 if the finger was lifted.
+func (p TabletPad) OnStrip(cb func(pad TabletPad, time time.Time, strip uint32, source TabletPadStripSource, position float64, mode uint32)) Listener {
+	return newListener(&p.p.events.strip, func(lis Listener, data unsafe.Pointer) {
+		event := (*C.struct_wlr_tablet_pad_strip_event)(data)
+		cb(p, time.UnixMilli(int64(event.time_msec)), uint32(event.strip), TabletPadStripSource(event.source), float64(event.position), uint32(event.mode))
+	})
+}
+
+// wlArrayStrings copies a wl_array of C strings.
+func wlArrayStrings(a *C.struct_wl_array) []string {
+	n := uintptr(a.size) / unsafe.Sizeof((*C.char)(nil))
+	if n == 0 {
+		return nil
+	}
+
+	strs := make([]string, 0, n)
+	for _, s := range unsafe.Slice((**C.char)(a.data), n) {
+		strs = append(strs, C.GoString(s))
+	}
+	return strs
+}
@@ -0,0 +1,244 @@
+package wlr
+
+//go:generate sh -c "wayland-scanner server-header $(pkg-config --variable=pkgdatadir wayland-protocols)/unstable/tablet/tablet-unstable-v2.xml tablet-unstable-v2-protocol.h"
+
+/*
+#include <wlr/types/wlr_tablet_v2.h>
+*/
+import "C"
+
+import (
+	"time"
+	"unsafe"
+)
+
+// TabletManagerV2 implements the tablet-unstable-v2 protocol, which
+// forwards tablet input, including pressure and tilt, to clients.
+// Each tablet device, pad, and tool must be registered with it for a
+// seat before its events can be sent.
+type TabletManagerV2 struct {
+	p *C.struct_wlr_tablet_manager_v2
+}
+
+func CreateTabletManagerV2(display Display) TabletManagerV2 {
+	checkThread()
+
+	p := C.wlr_tablet_v2_create(display.p)
+	return TabletManagerV2{p: p}
+}
+
+func (m TabletManagerV2) Valid() bool {
+	return m.p != nil
+}
+
+func (m TabletManagerV2) OnDestroy(cb func(TabletManagerV2)) Listener {
+	return newListener(&m.p.events.destroy, func(lis Listener, data unsafe.Pointer) {
+		cb(m)
+	})
+}
+
+// CreateTablet advertises dev, which must be a tablet, to the clients
+// of seat.
+func (m TabletManagerV2) CreateTablet(seat Seat, dev InputDevice) TabletV2Tablet {
+	checkThread()
+
+	p := C.wlr_tablet_create(m.p, seat.p, dev.p)
+	return TabletV2Tablet{p: p}
+}
+
+// CreatePad advertises dev, which must be a tablet pad, to the clients
+// of seat.
+func (m TabletManagerV2) CreatePad(seat Seat, dev InputDevice) TabletV2TabletPad {
+	checkThread()
+
+	p := C.wlr_tablet_pad_create(m.p, seat.p, dev.p)
+	return TabletV2TabletPad{p: p}
+}
+
+// CreateTool advertises tool to the clients of seat. It is usually
+// called the first time that the tool comes into proximity.
+func (m TabletManagerV2) CreateTool(seat Seat, tool TabletTool) TabletV2TabletTool {
+	checkThread()
+
+	p := C.wlr_tablet_tool_create(m.p, seat.p, tool.p)
+	return TabletV2TabletTool{p: p}
+}
+
+type TabletV2Tablet struct {
+	p *C.struct_wlr_tablet_v2_tablet
+}
+
+func (t TabletV2Tablet) Valid() bool {
+	return t.p != nil
+}
+
+func (t TabletV2Tablet) Tablet() Tablet {
+	return Tablet{p: t.p.wlr_tablet}
+}
+
+// AcceptsSurface returns true if the client that owns surface has
+// bound the tablet and can receive its events.
+func (t TabletV2Tablet) AcceptsSurface(surface Surface) bool {
+	return bool(C.wlr_surface_accepts_tablet_v2(t.p, surface.p))
+}
+
+type TabletV2TabletTool struct {
+	p *C.struct_wlr_tablet_v2_tablet_tool
+}
+
+func (t TabletV2TabletTool) Valid() bool {
+	return t.p != nil
+}
+
+func (t TabletV2TabletTool) Tool() TabletTool {
+	return TabletTool{p: t.p.wlr_tool}
+}
+
+// FocusedSurface returns the surface that the tool is currently in
+// proximity of, if any.
+func (t TabletV2TabletTool) FocusedSurface() Surface {
+	return Surface{p: t.p.focused_surface}
+}
+
+// OnSetCursor is called when a client requests a cursor image for the
+// tool while it has focus.
+func (t TabletV2TabletTool) OnSetCursor(cb func(client SeatClient, surface Surface, serial uint32, hotspotX, hotspotY int32)) Listener {
+	return newListener(&t.p.events.set_cursor, func(lis Listener, data unsafe.Pointer) {
+		event := (*C.struct_wlr_tablet_v2_event_cursor)(data)
+		cb(SeatClient{p: event.seat_client}, Surface{p: event.surface}, uint32(event.serial), int32(event.hotspot_x), int32(event.hotspot_y))
+	})
+}
+
+// NotifyProximityIn focuses the tool on surface, which must accept
+// tablet, and sends it a proximity_in event.
+func (t TabletV2TabletTool) NotifyProximityIn(tablet TabletV2Tablet, surface Surface) {
+	checkThread()
+
+	C.wlr_tablet_v2_tablet_tool_notify_proximity_in(t.p, tablet.p, surface.p)
+}
+
+func (t TabletV2TabletTool) NotifyProximityOut() {
+	checkThread()
+
+	C.wlr_tablet_v2_tablet_tool_notify_proximity_out(t.p)
+}
+
+func (t TabletV2TabletTool) NotifyDown() {
+	checkThread()
+
+	C.wlr_tablet_v2_tablet_tool_notify_down(t.p)
+}
+
+func (t TabletV2TabletTool) NotifyUp() {
+	checkThread()
+
+	C.wlr_tablet_v2_tablet_tool_notify_up(t.p)
+}
+
+// NotifyMotion sends the position of the tool in surface-local
+// coordinates.
+func (t TabletV2TabletTool) NotifyMotion(sx, sy float64) {
+	checkThread()
+
+	C.wlr_tablet_v2_tablet_tool_notify_motion(t.p, C.double(sx), C.double(sy))
+}
+
+// NotifyPressure sends the pressure of the tool, normalized to the
+// range [0, 1].
+func (t TabletV2TabletTool) NotifyPressure(pressure float64) {
+	checkThread()
+
+	C.wlr_tablet_v2_tablet_tool_notify_pressure(t.p, C.double(pressure))
+}
+
+// NotifyDistance sends the distance of the tool from the tablet,
+// normalized to the range [0, 1].
+func (t TabletV2TabletTool) NotifyDistance(distance float64) {
+	checkThread()
+
+	C.wlr_tablet_v2_tablet_tool_notify_distance(t.p, C.double(distance))
+}
+
+// NotifyTilt sends the tilt of the tool in degrees.
+func (t TabletV2TabletTool) NotifyTilt(x, y float64) {
+	checkThread()
+
+	C.wlr_tablet_v2_tablet_tool_notify_tilt(t.p, C.double(x), C.double(y))
+}
+
+// NotifyRotation sends the rotation of the tool in degrees.
+func (t TabletV2TabletTool) NotifyRotation(degrees float64) {
+	checkThread()
+
+	C.wlr_tablet_v2_tablet_tool_notify_rotation(t.p, C.double(degrees))
+}
+
+func (t TabletV2TabletTool) NotifySlider(position float64) {
+	checkThread()
+
+	C.wlr_tablet_v2_tablet_tool_notify_slider(t.p, C.double(position))
+}
+
+func (t TabletV2TabletTool) NotifyWheel(degrees float64, clicks int32) {
+	checkThread()
+
+	C.wlr_tablet_v2_tablet_tool_notify_wheel(t.p, C.double(degrees), C.int32_t(clicks))
+}
+
+func (t TabletV2TabletTool) NotifyButton(button uint32, state ButtonState) {
+	checkThread()
+
+	C.wlr_tablet_v2_tablet_tool_notify_button(t.p, C.uint32_t(button), C.enum_zwp_tablet_pad_v2_button_state(state))
+}
+
+type TabletV2TabletPad struct {
+	p *C.struct_wlr_tablet_v2_tablet_pad
+}
+
+func (p TabletV2TabletPad) Valid() bool {
+	return p.p != nil
+}
+
+func (p TabletV2TabletPad) Pad() TabletPad {
+	return TabletPad{p: p.p.wlr_pad}
+}
+
+// NotifyEnter focuses the pad on surface, which must accept tablet. It
+// returns the serial of the event.
+func (p TabletV2TabletPad) NotifyEnter(tablet TabletV2Tablet, surface Surface) uint32 {
+	checkThread()
+
+	return uint32(C.wlr_tablet_v2_tablet_pad_notify_enter(p.p, tablet.p, surface.p))
+}
+
+func (p TabletV2TabletPad) NotifyLeave(surface Surface) uint32 {
+	checkThread()
+
+	return uint32(C.wlr_tablet_v2_tablet_pad_notify_leave(p.p, surface.p))
+}
+
+func (p TabletV2TabletPad) NotifyButton(button uint32, time time.Time, state ButtonState) {
+	checkThread()
+
+	C.wlr_tablet_v2_tablet_pad_notify_button(p.p, C.size_t(button), C.uint32_t(time.UnixMilli()), C.enum_zwp_tablet_pad_v2_button_state(state))
+}
+
+func (p TabletV2TabletPad) NotifyRing(ring uint32, position float64, finger bool, time time.Time) {
+	checkThread()
+
+	C.wlr_tablet_v2_tablet_pad_notify_ring(p.p, C.uint32_t(ring), C.double(position), C.bool(finger), C.uint32_t(time.UnixMilli()))
+}
+
+func (p TabletV2TabletPad) NotifyStrip(strip uint32, position float64, finger bool, time time.Time) {
+	checkThread()
+
+	C.wlr_tablet_v2_tablet_pad_notify_strip(p.p, C.uint32_t(strip), C.double(position), C.bool(finger), C.uint32_t(time.UnixMilli()))
+}
+
+// NotifyMode notifies the focused client that the mode of a button
+// group has changed. It returns the serial of the event.
+func (p TabletV2TabletPad) NotifyMode(group, mode uint32, time time.Time) uint32 {
+	checkThread()
+
+	return uint32(C.wlr_tablet_v2_tablet_pad_notify_mode(p.p, C.size_t(group), C.uint32_t(mode), C.uint32_t(time.UnixMilli())))
+}
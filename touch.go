@@ -0,0 +1,72 @@
+package wlr
+
+/*
+#include <wlr/types/wlr_touch.h>
+*/
+import "C"
+
+import (
+	"time"
+	"unsafe"
+)
+
+// Touch is a touchscreen or other device that reports absolute touch
+// points. Coordinates reported by its events are normalized to the
+// range [0, 1].
+type Touch struct {
+	p *C.struct_wlr_touch
+}
+
+func (t Touch) Valid() bool {
+	return t.p != nil
+}
+
+func (t Touch) Base() InputDevice {
+	return InputDevice{p: &t.p.base}
+}
+
+// OutputName returns the name of the output that the device is
+// physically attached to, if known.
+func (t Touch) OutputName() string {
+	return C.GoString(t.p.output_name)
+}
+
+// Size returns the physical size of the device in millimeters, or
+// zero if it is unknown.
+func (t Touch) Size() (width, height float64) {
+	return float64(t.p.width_mm), float64(t.p.height_mm)
+}
+
+func (t Touch) OnDown(cb func(t Touch, time time.Time, touchID int32, x, y float64)) Listener {
+	return newListener(&t.p.events.down, func(lis Listener, data unsafe.Pointer) {
+		event := (*C.struct_wlr_touch_down_event)(data)
+		cb(t, time.UnixMilli(int64(event.time_msec)), int32(event.touch_id), float64(event.x), float64(event.y))
+	})
+}
+
+func (t Touch) OnUp(cb func(t Touch, time time.Time, touchID int32)) Listener {
+	return newListener(&t.p.events.up, func(lis Listener, data unsafe.Pointer) {
+		event := (*C.struct_wlr_touch_up_event)(data)
+		cb(t, time.UnixMilli(int64(event.time_msec)), int32(event.touch_id))
+	})
+}
+
+func (t Touch) OnMotion(cb func(t Touch, time time.Time, touchID int32, x, y float64)) Listener {
+	return newListener(&t.p.events.motion, func(lis Listener, data unsafe.Pointer) {
+		event := (*C.struct_wlr_touch_motion_event)(data)
+		cb(t, time.UnixMilli(int64(event.time_msec)), int32(event.touch_id), float64(event.x), float64(event.y))
+	})
+}
+
+func (t Touch) OnCancel(cb func(t Touch, time time.Time, touchID int32)) Listener {
+	return newListener(&t.p.events.cancel, func(lis Listener, data unsafe.Pointer) {
+		event := (*C.struct_wlr_touch_cancel_event)(data)
+		cb(t, time.UnixMilli(int64(event.time_msec)), int32(event.touch_id))
+	})
+}
+
+func (t Touch) OnFrame(cb func(t Touch)) Listener {
+	return newListener(&t.p.events.frame, func(lis Listener, data unsafe.Pointer) {
+		cb(t)
+	})
+}
@@ -0,0 +1,49 @@
+package wlr
+
+/*
+#include <wlr/types/wlr_virtual_keyboard_v1.h>
+*/
+import "C"
+
+import "unsafe"
+
+// VirtualKeyboardManagerV1 implements the virtual-keyboard-unstable-v1
+// protocol, which lets clients such as on-screen keyboards create
+// keyboards and send key events through them.
+type VirtualKeyboardManagerV1 struct {
+	p *C.struct_wlr_virtual_keyboard_manager_v1
+}
+
+func CreateVirtualKeyboardManagerV1(display Display) VirtualKeyboardManagerV1 {
+	checkThread()
+
+	p := C.wlr_virtual_keyboard_manager_v1_create(display.p)
+	return VirtualKeyboardManagerV1{p: p}
+}
+
+func (m VirtualKeyboardManagerV1) Valid() bool {
+	return m.p != nil
+}
+
+func (m VirtualKeyboardManagerV1) OnDestroy(cb func(VirtualKeyboardManagerV1)) Listener {
+	return newListener(&m.p.events.destroy, func(lis Listener, data unsafe.Pointer) {
+		cb(m)
+	})
+}
+
+// OnNewVirtualKeyboard is called when a client creates a virtual
+// keyboard on seat. The keyboard can be used like one created by a
+// backend, such as by passing it to Seat.SetKeyboard, and is destroyed
+// along with its base InputDevice.
+func (m VirtualKeyboardManagerV1) OnNewVirtualKeyboard(cb func(keyboard Keyboard, seat Seat)) Listener {
+	return newListener(&m.p.events.new_virtual_keyboard, func(lis Listener, data unsafe.Pointer) {
+		kb := (*C.struct_wlr_virtual_keyboard_v1)(data)
+		cb(Keyboard{p: &kb.keyboard}, Seat{p: kb.seat})
+	})
+}
+
+// IsVirtualKeyboard returns true if d was created by a client via
+// VirtualKeyboardManagerV1.
+func (d InputDevice) IsVirtualKeyboard() bool {
+	return C.wlr_input_device_get_virtual_keyboard(d.p) != nil
+}
@@ -0,0 +1,48 @@
+package wlr
+
+/*
+#include <wlr/types/wlr_virtual_pointer_v1.h>
+*/
+import "C"
+
+import "unsafe"
+
+// VirtualPointerManagerV1 implements the
+// wlr-virtual-pointer-unstable-v1 protocol, which lets clients create
+// pointers and send motion, button, and axis events through them.
+type VirtualPointerManagerV1 struct {
+	p *C.struct_wlr_virtual_pointer_manager_v1
+}
+
+func CreateVirtualPointerManagerV1(display Display) VirtualPointerManagerV1 {
+	checkThread()
+
+	p := C.wlr_virtual_pointer_manager_v1_create(display.p)
+	return VirtualPointerManagerV1{p: p}
+}
+
+func (m VirtualPointerManagerV1) Valid() bool {
+	return m.p != nil
+}
+
+func (m VirtualPointerManagerV1) OnDestroy(cb func(VirtualPointerManagerV1)) Listener {
+	return newListener(&m.p.events.destroy, func(lis Listener, data unsafe.Pointer) {
+		cb(m)
+	})
+}
+
+// OnNewVirtualPointer is called when a client creates a virtual
+// pointer. The pointer can be used like one created by a backend,
+// such as by passing its base InputDevice to Cursor.AttachInputDevice.
+// The client may suggest a seat and an output to map the pointer to,
+// either of which may be invalid.
+func (m VirtualPointerManagerV1) OnNewVirtualPointer(cb func(pointer Pointer, suggestedSeat Seat, suggestedOutput Output)) Listener {
+	return newListener(&m.p.events.new_virtual_pointer, func(lis Listener, data unsafe.Pointer) {
+		event := (*C.struct_wlr_virtual_pointer_v1_new_pointer_event)(data)
+		cb(
+			Pointer{p: &event.new_pointer.pointer},
+			Seat{p: event.suggested_seat},
+			Output{p: event.suggested_output},
+		)
+	})
+}
@@ -11,7 +11,6 @@ package wlr
 
 #include <wlr/util/box.h>
 #include <wlr/types/wlr_compositor.h>
-#include <wlr/types/wlr_data_device.h>
 #include <wlr/types/wlr_matrix.h>
 #include <wlr/util/edges.h>
 #include <wlr/xwayland.h>
@@ -22,7 +21,6 @@ import (
 	"image"
 	"image/color"
 	"iter"
-	"time"
 	"unsafe"
 )
 
@@ -75,52 +73,13 @@ func (m *Matrix) toC() [9]C.float {
 	return cm
 }
 
-type EventLoop struct {
-	p *C.struct_wl_event_loop
-}
-
-func (evl EventLoop) OnDestroy(cb func(EventLoop)) Listener {
-	lis := newListener(nil, func(lis Listener, data unsafe.Pointer) {
-		cb(evl)
-	})
-	C.wl_event_loop_add_destroy_listener(evl.p, &lis.p.lis)
-	return lis
-}
-
-func (evl EventLoop) Fd() uintptr {
-	return uintptr(C.wl_event_loop_get_fd(evl.p))
-}
-
-func (evl EventLoop) Dispatch(timeout time.Duration) {
-	var d int
-	if timeout >= 0 {
-		d = int(timeout / time.Millisecond)
-	} else {
-		d = -1
-	}
-	C.wl_event_loop_dispatch(evl.p, C.int(d))
-}
-
-type DataDeviceManager struct {
-	p *C.struct_wlr_data_device_manager
-}
-
-func CreateDataDeviceManager(display Display) DataDeviceManager {
-	p := C.wlr_data_device_manager_create(display.p)
-	return DataDeviceManager{p: p}
-}
-
-func (m DataDeviceManager) OnDestroy(cb func(DataDeviceManager)) Listener {
-	return newListener(&m.p.events.destroy, func(lis Listener, data unsafe.Pointer) {
-		cb(m)
-	})
-}
-
 type Compositor struct {
 	p *C.struct_wlr_compositor
 }
 
 func CreateCompositor(display Display, version uint32, renderer Renderer) Compositor {
+	checkThread()
+
 	p := C.wlr_compositor_create(display.p, C.uint32_t(version), renderer.p)
 	return Compositor{p: p}
 }
@@ -141,11 +100,19 @@ func colorToC(c color.Color) [4]C.float {
 	}
 }
 
+// boxToC converts r to a wlr_box. It returns nil for the zero
+// rectangle, which many functions treat as meaning no box.
 func boxToC(r image.Rectangle) *C.struct_wlr_box {
 	if r == (image.Rectangle{}) {
 		return nil
 	}
 
+	return newBox(r)
+}
+
+// newBox is like boxToC but always returns a box, for functions that
+// don't accept nil.
+func newBox(r image.Rectangle) *C.struct_wlr_box {
 	r = r.Canon()
 	return &C.struct_wlr_box{
 		x:      C.int(r.Min.X),
@@ -168,38 +135,6 @@ func boxFromC(box *C.struct_wlr_box) image.Rectangle {
 	)
 }
 
-func rectToC(r image.Rectangle) (cr C.pixman_region32_t) {
-	r = r.Canon()
-	C.pixman_region32_init_rect(
-		&cr,
-		C.int(r.Min.X),
-		C.int(r.Max.Y),
-		C.uint(r.Dx()),
-		C.uint(r.Dy()),
-	)
-	return
-}
-
-type Resource struct {
-	p *C.struct_wl_resource
-}
-
-func (r Resource) GetClient() Client {
-	return Client{p: C.wl_resource_get_client(r.p)}
-}
-
-type Client struct {
-	p *C.struct_wl_client
-}
-
-func (c Client) GetCredentials() (pid, uid, gid int) {
-	var cpid C.pid_t
-	var cuid C.uid_t
-	var cgid C.gid_t
-	C.wl_client_get_credentials(c.p, &cpid, &cuid, &cgid)
-	return int(cpid), int(cuid), int(cgid)
-}
-
 func container[T any](list *C.struct_wl_list, offset int) *T {
 	return (*T)(unsafe.Add(unsafe.Pointer(list), -offset))
 }
@@ -26,6 +26,8 @@ type XCursorManager struct {
 }
 
 func CreateXCursorManager(name string, size uint32) XCursorManager {
+	checkThread()
+
 	var cname *C.char
 	if name != "" {
 		cname = C.CString(name)
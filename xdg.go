@@ -49,6 +49,55 @@ const (
 	XDGSurfaceRolePopup    XDGSurfaceRole = C.WLR_XDG_SURFACE_ROLE_POPUP
 )
 
+type XDGToplevelWMCapabilities uint32
+
+const (
+	XDGToplevelWMCapabilitiesWindowMenu XDGToplevelWMCapabilities = C.WLR_XDG_TOPLEVEL_WM_CAPABILITIES_WINDOW_MENU
+	XDGToplevelWMCapabilitiesMaximize   XDGToplevelWMCapabilities = C.WLR_XDG_TOPLEVEL_WM_CAPABILITIES_MAXIMIZE
+	XDGToplevelWMCapabilitiesFullscreen XDGToplevelWMCapabilities = C.WLR_XDG_TOPLEVEL_WM_CAPABILITIES_FULLSCREEN
+	XDGToplevelWMCapabilitiesMinimize   XDGToplevelWMCapabilities = C.WLR_XDG_TOPLEVEL_WM_CAPABILITIES_MINIMIZE
+)
+
+type XDGPositionerAnchor uint32
+
+const (
+	XDGPositionerAnchorNone        XDGPositionerAnchor = C.XDG_POSITIONER_ANCHOR_NONE
+	XDGPositionerAnchorTop         XDGPositionerAnchor = C.XDG_POSITIONER_ANCHOR_TOP
+	XDGPositionerAnchorBottom      XDGPositionerAnchor = C.XDG_POSITIONER_ANCHOR_BOTTOM
+	XDGPositionerAnchorLeft        XDGPositionerAnchor = C.XDG_POSITIONER_ANCHOR_LEFT
+	XDGPositionerAnchorRight       XDGPositionerAnchor = C.XDG_POSITIONER_ANCHOR_RIGHT
+	XDGPositionerAnchorTopLeft     XDGPositionerAnchor = C.XDG_POSITIONER_ANCHOR_TOP_LEFT
+	XDGPositionerAnchorBottomLeft  XDGPositionerAnchor = C.XDG_POSITIONER_ANCHOR_BOTTOM_LEFT
+	XDGPositionerAnchorTopRight    XDGPositionerAnchor = C.XDG_POSITIONER_ANCHOR_TOP_RIGHT
+	XDGPositionerAnchorBottomRight XDGPositionerAnchor = C.XDG_POSITIONER_ANCHOR_BOTTOM_RIGHT
+)
+
+type XDGPositionerGravity uint32
+
+const (
+	XDGPositionerGravityNone        XDGPositionerGravity = C.XDG_POSITIONER_GRAVITY_NONE
+	XDGPositionerGravityTop         XDGPositionerGravity = C.XDG_POSITIONER_GRAVITY_TOP
+	XDGPositionerGravityBottom      XDGPositionerGravity = C.XDG_POSITIONER_GRAVITY_BOTTOM
+	XDGPositionerGravityLeft        XDGPositionerGravity = C.XDG_POSITIONER_GRAVITY_LEFT
+	XDGPositionerGravityRight       XDGPositionerGravity = C.XDG_POSITIONER_GRAVITY_RIGHT
+	XDGPositionerGravityTopLeft     XDGPositionerGravity = C.XDG_POSITIONER_GRAVITY_TOP_LEFT
+	XDGPositionerGravityBottomLeft  XDGPositionerGravity = C.XDG_POSITIONER_GRAVITY_BOTTOM_LEFT
+	XDGPositionerGravityTopRight    XDGPositionerGravity = C.XDG_POSITIONER_GRAVITY_TOP_RIGHT
+	XDGPositionerGravityBottomRight XDGPositionerGravity = C.XDG_POSITIONER_GRAVITY_BOTTOM_RIGHT
+)
+
+type XDGPositionerConstraintAdjustment uint32
+
+const (
+	XDGPositionerConstraintAdjustmentNone    XDGPositionerConstraintAdjustment = C.XDG_POSITIONER_CONSTRAINT_ADJUSTMENT_NONE
+	XDGPositionerConstraintAdjustmentSlideX  XDGPositionerConstraintAdjustment = C.XDG_POSITIONER_CONSTRAINT_ADJUSTMENT_SLIDE_X
+	XDGPositionerConstraintAdjustmentSlideY  XDGPositionerConstraintAdjustment = C.XDG_POSITIONER_CONSTRAINT_ADJUSTMENT_SLIDE_Y
+	XDGPositionerConstraintAdjustmentFlipX   XDGPositionerConstraintAdjustment = C.XDG_POSITIONER_CONSTRAINT_ADJUSTMENT_FLIP_X
+	XDGPositionerConstraintAdjustmentFlipY   XDGPositionerConstraintAdjustment = C.XDG_POSITIONER_CONSTRAINT_ADJUSTMENT_FLIP_Y
+	XDGPositionerConstraintAdjustmentResizeX XDGPositionerConstraintAdjustment = C.XDG_POSITIONER_CONSTRAINT_ADJUSTMENT_RESIZE_X
+	XDGPositionerConstraintAdjustmentResizeY XDGPositionerConstraintAdjustment = C.XDG_POSITIONER_CONSTRAINT_ADJUSTMENT_RESIZE_Y
+)
+
 type XDGSurfaceWalkFunc func(surface Surface, sx int, sy int)
 
 type XDGShell struct {
@@ -56,6 +105,8 @@ type XDGShell struct {
 }
 
 func CreateXDGShell(display Display, version uint32) XDGShell {
+	checkThread()
+
 	p := C.wlr_xdg_shell_create(display.p, C.uint32_t(version))
 	return XDGShell{p: p}
 }
@@ -116,6 +167,33 @@ func (s XDGSurface) Current() XDGSurfaceState {
 	return XDGSurfaceState{v: s.p.current}
 }
 
+// Pending returns the state that will become current on the next
+// commit of the surface.
+func (s XDGSurface) Pending() XDGSurfaceState {
+	return XDGSurfaceState{v: s.p.pending}
+}
+
+// Configured returns true if the client has acknowledged at least one
+// configure event.
+func (s XDGSurface) Configured() bool {
+	return bool(s.p.configured)
+}
+
+// ScheduledSerial returns the serial of the configure that has been
+// scheduled but not yet sent. It is only meaningful while a configure
+// is scheduled.
+func (s XDGSurface) ScheduledSerial() uint32 {
+	return uint32(s.p.scheduled_serial)
+}
+
+// ScheduleConfigure schedules a configure event to be sent to the
+// client with the surface's current pending state, even if nothing
+// has changed, and returns its serial. Changes made before the event
+// is sent are merged into it.
+func (s XDGSurface) ScheduleConfigure() uint32 {
+	return uint32(C.wlr_xdg_surface_schedule_configure(s.p))
+}
+
 func (s XDGSurface) Toplevel() XDGToplevel {
 	p := *(*unsafe.Pointer)(unsafe.Pointer(&s.p.anon0[0]))
 	return XDGToplevel{p: (*C.struct_wlr_xdg_toplevel)(p)}
@@ -126,27 +204,60 @@ func (s XDGSurface) Popup() XDGPopup {
 	return XDGPopup{p: (*C.struct_wlr_xdg_popup)(p)}
 }
 
-func (s XDGToplevel) SetActivated(activated bool) {
-	C.wlr_xdg_toplevel_set_activated(s.p, C.bool(activated))
+// SetActivated schedules a configure event for the toplevel and
+// returns its serial. The other setters that return a serial behave
+// the same way.
+func (s XDGToplevel) SetActivated(activated bool) uint32 {
+	return uint32(C.wlr_xdg_toplevel_set_activated(s.p, C.bool(activated)))
 }
 
-func (s XDGToplevel) SetResizing(resizing bool) {
-	C.wlr_xdg_toplevel_set_resizing(s.p, C.bool(resizing))
+func (s XDGToplevel) SetResizing(resizing bool) uint32 {
+	return uint32(C.wlr_xdg_toplevel_set_resizing(s.p, C.bool(resizing)))
 }
 
-func (s XDGToplevel) SetSize(width int32, height int32) {
-	C.wlr_xdg_toplevel_set_size(s.p, C.int32_t(width), C.int32_t(height))
+func (s XDGToplevel) SetSize(width int32, height int32) uint32 {
+	return uint32(C.wlr_xdg_toplevel_set_size(s.p, C.int32_t(width), C.int32_t(height)))
 }
 
-func (s XDGToplevel) SetTiled(edges Edges) {
-	C.wlr_xdg_toplevel_set_tiled(s.p, C.uint32_t(edges))
+func (s XDGToplevel) SetTiled(edges Edges) uint32 {
+	return uint32(C.wlr_xdg_toplevel_set_tiled(s.p, C.uint32_t(edges)))
 }
 
-func (s XDGToplevel) SetMaximized(maximized bool) {
-	C.wlr_xdg_toplevel_set_maximized(s.p, C.bool(maximized))
+func (s XDGToplevel) SetMaximized(maximized bool) uint32 {
+	return uint32(C.wlr_xdg_toplevel_set_maximized(s.p, C.bool(maximized)))
+}
+
+func (s XDGToplevel) SetFullscreen(fullscreen bool) uint32 {
+	return uint32(C.wlr_xdg_toplevel_set_fullscreen(s.p, C.bool(fullscreen)))
+}
+
+// SetSuspended tells the client whether or not the toplevel is
+// visible, allowing it to stop rendering frames while it isn't.
+func (s XDGToplevel) SetSuspended(suspended bool) uint32 {
+	return uint32(C.wlr_xdg_toplevel_set_suspended(s.p, C.bool(suspended)))
+}
+
+// SetBounds tells the client the largest size that the toplevel
+// should be, such as the usable area of its output.
+func (s XDGToplevel) SetBounds(width int32, height int32) uint32 {
+	return uint32(C.wlr_xdg_toplevel_set_bounds(s.p, C.int32_t(width), C.int32_t(height)))
+}
+
+// SetWMCapabilities tells the client which window management actions
+// the compositor supports for the toplevel.
+func (s XDGToplevel) SetWMCapabilities(caps XDGToplevelWMCapabilities) uint32 {
+	return uint32(C.wlr_xdg_toplevel_set_wm_capabilities(s.p, C.uint32_t(caps)))
+}
+
+// SetParent sets the parent of the toplevel. An invalid parent unsets
+// it. It returns false if doing so would create a loop.
+func (s XDGToplevel) SetParent(parent XDGToplevel) bool {
+	return bool(C.wlr_xdg_toplevel_set_parent(s.p, parent.p))
 }
 
 func (s XDGToplevel) SendClose() {
+	checkThread()
+
 	C.wlr_xdg_toplevel_send_close(s.p)
 }
 
@@ -176,6 +287,31 @@ func (s XDGSurface) OnPingTimeout(cb func(XDGSurface)) Listener {
 	})
 }
 
+// OnConfigure is called when a configure event is sent to the
+// client.
+func (s XDGSurface) OnConfigure(cb func(XDGSurface, XDGSurfaceConfigure)) Listener {
+	return newListener(&s.p.events.configure, func(lis Listener, data unsafe.Pointer) {
+		cb(s, XDGSurfaceConfigure{p: (*C.struct_wlr_xdg_surface_configure)(data)})
+	})
+}
+
+// OnAckConfigure is called when the client acknowledges a configure
+// event. The state of the configure is not applied until the client
+// next commits the surface.
+func (s XDGSurface) OnAckConfigure(cb func(XDGSurface, XDGSurfaceConfigure)) Listener {
+	return newListener(&s.p.events.ack_configure, func(lis Listener, data unsafe.Pointer) {
+		cb(s, XDGSurfaceConfigure{p: (*C.struct_wlr_xdg_surface_configure)(data)})
+	})
+}
+
+// OnCommit is called each time the client commits the surface, after
+// any acknowledged configure has been applied to its current state.
+func (s XDGSurface) OnCommit(cb func(XDGSurface)) Listener {
+	return newListener(&s.p.surface.events.commit, func(lis Listener, data unsafe.Pointer) {
+		cb(s)
+	})
+}
+
 func (s XDGSurface) OnNewPopup(cb func(XDGSurface, XDGPopup)) Listener {
 	return newListener(&s.p.events.new_popup, func(lis Listener, data unsafe.Pointer) {
 		cb(
@@ -195,10 +331,180 @@ type XDGPopup struct {
 	p *C.struct_wlr_xdg_popup
 }
 
+func (p XDGPopup) Valid() bool {
+	return p.p != nil
+}
+
+func (p XDGPopup) Base() XDGSurface {
+	return XDGSurface{p: p.p.base}
+}
+
 func (p XDGPopup) Parent() Surface {
 	return Surface{p: p.p.parent}
 }
 
+// Seat returns the seat that the popup has an explicit grab on, if
+// any. Input on that seat is redirected to the popup until it is
+// dismissed. Seat.OnPointerGrabBegin and the related methods report
+// when the grab is taken and released.
+func (p XDGPopup) Seat() Seat {
+	return Seat{p: p.p.seat}
+}
+
+// Destroy dismisses the popup, sending it a popup_done event, and
+// destroys it along with any of its child popups.
+func (p XDGPopup) Destroy() {
+	C.wlr_xdg_popup_destroy(p.p)
+}
+
+// OnReposition is called when the client asks for the popup to be
+// repositioned using new positioner rules, which are available from
+// Scheduled.
+func (p XDGPopup) OnReposition(cb func(XDGPopup)) Listener {
+	return newListener(&p.p.events.reposition, func(lis Listener, data unsafe.Pointer) {
+		cb(p)
+	})
+}
+
+func (p XDGPopup) Current() XDGPopupState {
+	return XDGPopupState{v: p.p.current}
+}
+
+func (p XDGPopup) Pending() XDGPopupState {
+	return XDGPopupState{v: p.p.pending}
+}
+
+// Scheduled returns the configure that will be sent to the client
+// next, including the positioner rules that it was computed from.
+func (p XDGPopup) Scheduled() XDGPopupConfigure {
+	return XDGPopupConfigure{v: p.p.scheduled}
+}
+
+// Geometry returns the current position and size of the popup
+// relative to its parent.
+func (p XDGPopup) Geometry() image.Rectangle {
+	return boxFromC(&p.p.current.geometry)
+}
+
+// Position returns the position of the popup relative to its parent
+// surface.
+func (p XDGPopup) Position() (sx, sy float64) {
+	var csx, csy C.double
+	C.wlr_xdg_popup_get_position(p.p, &csx, &csy)
+	return float64(csx), float64(csy)
+}
+
+// ToplevelCoords converts coordinates relative to the popup into
+// coordinates relative to the toplevel that it belongs to.
+func (p XDGPopup) ToplevelCoords(popupSX, popupSY int) (toplevelSX, toplevelSY int) {
+	var ctx, cty C.int
+	C.wlr_xdg_popup_get_toplevel_coords(p.p, C.int(popupSX), C.int(popupSY), &ctx, &cty)
+	return int(ctx), int(cty)
+}
+
+// UnconstrainFromBox adjusts the scheduled geometry of the popup so
+// that it fits within box, which is in the coordinate space of the
+// popup's toplevel, using the adjustments allowed by its positioner.
+// This is usually used to keep a popup on the visible area of an
+// output.
+func (p XDGPopup) UnconstrainFromBox(box image.Rectangle) {
+	C.wlr_xdg_popup_unconstrain_from_box(p.p, newBox(box))
+}
+
+type XDGPopupState struct {
+	v C.struct_wlr_xdg_popup_state
+}
+
+func (s XDGPopupState) Geometry() image.Rectangle {
+	return boxFromC(&s.v.geometry)
+}
+
+// Reactive returns whether the popup should be repositioned when its
+// parent moves.
+func (s XDGPopupState) Reactive() bool {
+	return bool(s.v.reactive)
+}
+
+type XDGPopupConfigure struct {
+	v C.struct_wlr_xdg_popup_configure
+}
+
+func (c XDGPopupConfigure) Geometry() image.Rectangle {
+	return boxFromC(&c.v.geometry)
+}
+
+func (c XDGPopupConfigure) Rules() XDGPositionerRules {
+	return XDGPositionerRules{v: c.v.rules}
+}
+
+// RepositionToken returns the token of the reposition request that
+// the configure is a response to, if any.
+func (c XDGPopupConfigure) RepositionToken() (token uint32, ok bool) {
+	ok = c.v.fields&C.WLR_XDG_POPUP_CONFIGURE_REPOSITION_TOKEN != 0
+	return uint32(c.v.reposition_token), ok
+}
+
+// XDGPositionerRules are the rules that a client provides for
+// positioning a popup relative to its parent.
+type XDGPositionerRules struct {
+	v C.struct_wlr_xdg_positioner_rules
+}
+
+// AnchorRect returns the rectangle, relative to the parent's window
+// geometry, that the popup is anchored to.
+func (r XDGPositionerRules) AnchorRect() image.Rectangle {
+	return boxFromC(&r.v.anchor_rect)
+}
+
+func (r XDGPositionerRules) Anchor() XDGPositionerAnchor {
+	return XDGPositionerAnchor(r.v.anchor)
+}
+
+func (r XDGPositionerRules) Gravity() XDGPositionerGravity {
+	return XDGPositionerGravity(r.v.gravity)
+}
+
+func (r XDGPositionerRules) ConstraintAdjustment() XDGPositionerConstraintAdjustment {
+	return XDGPositionerConstraintAdjustment(r.v.constraint_adjustment)
+}
+
+func (r XDGPositionerRules) Reactive() bool {
+	return bool(r.v.reactive)
+}
+
+func (r XDGPositionerRules) Size() (width, height int32) {
+	return int32(r.v.size.width), int32(r.v.size.height)
+}
+
+func (r XDGPositionerRules) ParentSize() (width, height int32) {
+	return int32(r.v.parent_size.width), int32(r.v.parent_size.height)
+}
+
+func (r XDGPositionerRules) Offset() (x, y int32) {
+	return int32(r.v.offset.x), int32(r.v.offset.y)
+}
+
+func (r XDGPositionerRules) ParentConfigureSerial() (serial uint32, ok bool) {
+	return uint32(r.v.parent_configure_serial), bool(r.v.has_parent_configure_serial)
+}
+
+// Geometry returns the geometry of a popup positioned according to
+// the rules without any constraints applied.
+func (r XDGPositionerRules) Geometry() image.Rectangle {
+	var box C.struct_wlr_box
+	C.wlr_xdg_positioner_rules_get_geometry(&r.v, &box)
+	return boxFromC(&box)
+}
+
+// UnconstrainBox adjusts box, which was positioned according to the
+// rules, so that it fits within constraint as well as the rules
+// allow.
+func (r XDGPositionerRules) UnconstrainBox(constraint, box image.Rectangle) image.Rectangle {
+	cbox := newBox(box)
+	C.wlr_xdg_positioner_rules_unconstrain_box(&r.v, newBox(constraint), cbox)
+	return boxFromC(cbox)
+}
+
 type XDGToplevel struct {
 	p *C.struct_wlr_xdg_toplevel
 }
@@ -231,9 +537,35 @@ func (t XDGToplevel) OnRequestMaximize(cb func(XDGToplevel)) Listener {
 	})
 }
 
+func (t XDGToplevel) OnRequestFullscreen(cb func(XDGToplevel)) Listener {
+	return newListener(&t.p.events.request_fullscreen, func(lis Listener, data unsafe.Pointer) {
+		cb(t)
+	})
+}
+
+func (t XDGToplevel) OnRequestShowWindowMenu(cb func(t XDGToplevel, client SeatClient, serial uint32, x, y int32)) Listener {
+	return newListener(&t.p.events.request_show_window_menu, func(lis Listener, data unsafe.Pointer) {
+		event := (*C.struct_wlr_xdg_toplevel_show_window_menu_event)(data)
+		client := SeatClient{p: event.seat}
+		cb(t, client, uint32(event.serial), int32(event.x), int32(event.y))
+	})
+}
+
 func (t XDGToplevel) OnSetTitle(cb func(XDGToplevel, string)) Listener {
 	return newListener(&t.p.events.set_title, func(lis Listener, data unsafe.Pointer) {
-		cb(t, C.GoString((*C.char)(data)))
+		cb(t, t.Title())
+	})
+}
+
+func (t XDGToplevel) OnSetAppID(cb func(XDGToplevel, string)) Listener {
+	return newListener(&t.p.events.set_app_id, func(lis Listener, data unsafe.Pointer) {
+		cb(t, t.AppID())
+	})
+}
+
+func (t XDGToplevel) OnSetParent(cb func(t XDGToplevel, parent XDGToplevel)) Listener {
+	return newListener(&t.p.events.set_parent, func(lis Listener, data unsafe.Pointer) {
+		cb(t, t.Parent())
 	})
 }
 
@@ -249,10 +581,38 @@ func (t XDGToplevel) Title() string {
 	return C.GoString(t.p.title)
 }
 
+func (t XDGToplevel) AppID() string {
+	return C.GoString(t.p.app_id)
+}
+
+// Parent returns the toplevel's parent, such as the main window of a
+// dialog. The returned value is invalid if it has no parent.
+func (t XDGToplevel) Parent() XDGToplevel {
+	return XDGToplevel{p: t.p.parent}
+}
+
 func (t XDGToplevel) Current() XDGToplevelState {
 	return XDGToplevelState{v: t.p.current}
 }
 
+// Pending returns the state that will become current on the next
+// commit of the toplevel's surface.
+func (t XDGToplevel) Pending() XDGToplevelState {
+	return XDGToplevelState{v: t.p.pending}
+}
+
+// Scheduled returns the configure that will be sent to the client
+// next.
+func (t XDGToplevel) Scheduled() XDGToplevelConfigure {
+	return XDGToplevelConfigure{v: t.p.scheduled}
+}
+
+// Requested returns the state that the client has requested, such as
+// for a toplevel that asked to be fullscreen before being mapped.
+func (t XDGToplevel) Requested() XDGToplevelRequested {
+	return XDGToplevelRequested{v: t.p.requested}
+}
+
 type XDGToplevelState struct {
 	v C.struct_wlr_xdg_toplevel_state
 }
@@ -261,6 +621,26 @@ func (s XDGToplevelState) Activated() bool {
 	return bool(s.v.activated)
 }
 
+func (s XDGToplevelState) Maximized() bool {
+	return bool(s.v.maximized)
+}
+
+func (s XDGToplevelState) Fullscreen() bool {
+	return bool(s.v.fullscreen)
+}
+
+func (s XDGToplevelState) Resizing() bool {
+	return bool(s.v.resizing)
+}
+
+func (s XDGToplevelState) Suspended() bool {
+	return bool(s.v.suspended)
+}
+
+func (s XDGToplevelState) Tiled() Edges {
+	return Edges(s.v.tiled)
+}
+
 func (s XDGToplevelState) Width() uint32 {
 	return uint32(s.v.width)
 }
@@ -285,11 +665,84 @@ func (s XDGToplevelState) MaxHeight() uint32 {
 	return uint32(s.v.max_height)
 }
 
+type XDGToplevelConfigure struct {
+	v C.struct_wlr_xdg_toplevel_configure
+}
+
+func (c XDGToplevelConfigure) Activated() bool {
+	return bool(c.v.activated)
+}
+
+func (c XDGToplevelConfigure) Maximized() bool {
+	return bool(c.v.maximized)
+}
+
+func (c XDGToplevelConfigure) Fullscreen() bool {
+	return bool(c.v.fullscreen)
+}
+
+func (c XDGToplevelConfigure) Resizing() bool {
+	return bool(c.v.resizing)
+}
+
+func (c XDGToplevelConfigure) Suspended() bool {
+	return bool(c.v.suspended)
+}
+
+func (c XDGToplevelConfigure) Tiled() Edges {
+	return Edges(c.v.tiled)
+}
+
+func (c XDGToplevelConfigure) Width() int32 {
+	return int32(c.v.width)
+}
+
+func (c XDGToplevelConfigure) Height() int32 {
+	return int32(c.v.height)
+}
+
+// Bounds returns the bounds that are sent with the configure, if any.
+func (c XDGToplevelConfigure) Bounds() (width, height int32, ok bool) {
+	ok = c.v.fields&C.WLR_XDG_TOPLEVEL_CONFIGURE_FIELD_BOUNDS != 0
+	return int32(c.v.bounds.width), int32(c.v.bounds.height), ok
+}
+
+// WMCapabilities returns the capabilities that are sent with the
+// configure, if any.
+func (c XDGToplevelConfigure) WMCapabilities() (caps XDGToplevelWMCapabilities, ok bool) {
+	ok = c.v.fields&C.WLR_XDG_TOPLEVEL_CONFIGURE_FIELD_WM_CAPABILITIES != 0
+	return XDGToplevelWMCapabilities(c.v.wm_capabilities), ok
+}
+
+type XDGToplevelRequested struct {
+	v C.struct_wlr_xdg_toplevel_requested
+}
+
+func (r XDGToplevelRequested) Maximized() bool {
+	return bool(r.v.maximized)
+}
+
+func (r XDGToplevelRequested) Minimized() bool {
+	return bool(r.v.minimized)
+}
+
+func (r XDGToplevelRequested) Fullscreen() bool {
+	return bool(r.v.fullscreen)
+}
+
+// FullscreenOutput returns the output that the client requested to be
+// fullscreen on. It is invalid if the client didn't specify one.
+func (r XDGToplevelRequested) FullscreenOutput() Output {
+	return Output{p: r.v.fullscreen_output}
+}
+
 type XDGOutputManagerV1 struct {
 	p *C.struct_wlr_xdg_output_manager_v1
 }
 
 func CreateXDGOutputManagerV1(display Display, layout OutputLayout) XDGOutputManagerV1 {
+	checkThread()
+
 	p := C.wlr_xdg_output_manager_v1_create(display.p, layout.p)
 	return XDGOutputManagerV1{p: p}
 }
@@ -307,3 +760,52 @@ type XDGSurfaceState struct {
 func (s XDGSurfaceState) Geometry() image.Rectangle {
 	return boxFromC(&s.v.geometry)
 }
+
+// ConfigureSerial returns the serial of the last configure that was
+// acknowledged by the client as of this state.
+func (s XDGSurfaceState) ConfigureSerial() uint32 {
+	return uint32(s.v.configure_serial)
+}
+
+// XDGSurfaceConfigure is a configure event that has been sent to a
+// client. It is only valid for the duration of the callback that it
+// is passed to.
+type XDGSurfaceConfigure struct {
+	p *C.struct_wlr_xdg_surface_configure
+}
+
+func (c XDGSurfaceConfigure) Surface() XDGSurface {
+	return XDGSurface{p: c.p.surface}
+}
+
+func (c XDGSurfaceConfigure) Serial() uint32 {
+	return uint32(c.p.serial)
+}
+
+// Toplevel returns the toplevel state of the configure. It returns
+// false if the surface is not a toplevel.
+func (c XDGSurfaceConfigure) Toplevel() (XDGToplevelConfigure, bool) {
+	if c.p.surface == nil || c.p.surface.role != C.WLR_XDG_SURFACE_ROLE_TOPLEVEL {
+		return XDGToplevelConfigure{}, false
+	}
+
+	p := *(**C.struct_wlr_xdg_toplevel_configure)(unsafe.Pointer(&c.p.anon0[0]))
+	if p == nil {
+		return XDGToplevelConfigure{}, false
+	}
+	return XDGToplevelConfigure{v: *p}, true
+}
+
+// Popup returns the popup state of the configure. It returns false if
+// the surface is not a popup.
+func (c XDGSurfaceConfigure) Popup() (XDGPopupConfigure, bool) {
+	if c.p.surface == nil || c.p.surface.role != C.WLR_XDG_SURFACE_ROLE_POPUP {
+		return XDGPopupConfigure{}, false
+	}
+
+	p := *(**C.struct_wlr_xdg_popup_configure)(unsafe.Pointer(&c.p.anon0[0]))
+	if p == nil {
+		return XDGPopupConfigure{}, false
+	}
+	return XDGPopupConfigure{v: *p}, true
+}
@@ -20,6 +20,8 @@ type XDGDecorationManagerV1 struct {
 }
 
 func CreateXDGDecorationManagerV1(display Display) XDGDecorationManagerV1 {
+	checkThread()
+
 	p := C.wlr_xdg_decoration_manager_v1_create(display.p)
 	return XDGDecorationManagerV1{p: p}
 }
@@ -20,6 +20,8 @@ type Xwayland struct {
 }
 
 func CreateXwayland(display Display, compositor Compositor, lazy bool) Xwayland {
+	checkThread()
+
 	p := C.wlr_xwayland_create(display.p, compositor.p, C.bool(lazy))
 	return Xwayland{p: p}
 }